@@ -0,0 +1,134 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/oSethoum/validator"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "validatetag",
+	Doc:      "check the validate and mod struct tags of github.com/oSethoum/validator",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// modifiers lists the modifiers registered by the program with
+// RegisterModifier, nfc is known as it is registered by importing its package.
+var modifiers string
+
+func init() {
+	Analyzer.Flags.StringVar(&modifiers, "modifiers", "", "comma separated modifiers registered by the program")
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	inspect.Preorder([]ast.Node{(*ast.StructType)(nil)}, func(n ast.Node) {
+		st, ok := pass.TypesInfo.TypeOf(n.(*ast.StructType)).(*types.Struct)
+		if !ok {
+			return
+		}
+		for _, field := range n.(*ast.StructType).Fields.List {
+			if field.Tag == nil {
+				continue
+			}
+			value, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				continue
+			}
+			tags := reflect.StructTag(value)
+			mod, ok := tags.Lookup("mod")
+			if !ok {
+				mod, ok = tags.Lookup("sanitize")
+			}
+			if ok {
+				known := append(strings.Split(modifiers, ","), "nfc")
+				for _, err := range validator.LintModifiers(mod, known...) {
+					pass.Reportf(field.Tag.Pos(), "validate: %v", err)
+				}
+			}
+			tag, ok := tags.Lookup("validate")
+			if !ok {
+				continue
+			}
+			lintField := validator.LintField{
+				HasField: func(name string) bool { return hasField(st, name) },
+			}
+			lintField.Kind, lintField.Elem = kinds(pass.TypesInfo.TypeOf(field.Type))
+			for _, err := range validator.LintTag(tag, lintField) {
+				pass.Reportf(field.Tag.Pos(), "validate: %v", err)
+			}
+		}
+	})
+	return nil, nil
+}
+
+func kinds(t types.Type) (reflect.Kind, reflect.Kind) {
+	if p, ok := t.Underlying().(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		return basicKinds[u.Kind()], reflect.Invalid
+	case *types.Slice:
+		elem, _ := kinds(u.Elem())
+		return reflect.Slice, elem
+	case *types.Array:
+		elem, _ := kinds(u.Elem())
+		return reflect.Array, elem
+	case *types.Struct:
+		return reflect.Struct, reflect.Invalid
+	case *types.Map:
+		return reflect.Map, reflect.Invalid
+	case *types.Interface:
+		return reflect.Interface, reflect.Invalid
+	case *types.Signature:
+		return reflect.Func, reflect.Invalid
+	case *types.Chan:
+		return reflect.Chan, reflect.Invalid
+	}
+	return reflect.Invalid, reflect.Invalid
+}
+
+var basicKinds = map[types.BasicKind]reflect.Kind{
+	types.Bool:          reflect.Bool,
+	types.Int:           reflect.Int,
+	types.Int8:          reflect.Int8,
+	types.Int16:         reflect.Int16,
+	types.Int32:         reflect.Int32,
+	types.Int64:         reflect.Int64,
+	types.Uint:          reflect.Uint,
+	types.Uint8:         reflect.Uint8,
+	types.Uint16:        reflect.Uint16,
+	types.Uint32:        reflect.Uint32,
+	types.Uint64:        reflect.Uint64,
+	types.Uintptr:       reflect.Uintptr,
+	types.Float32:       reflect.Float32,
+	types.Float64:       reflect.Float64,
+	types.Complex64:     reflect.Complex64,
+	types.Complex128:    reflect.Complex128,
+	types.String:        reflect.String,
+	types.UnsafePointer: reflect.UnsafePointer,
+}
+
+// hasField reports whether s has a field named name, promoted fields included
+// as they are found by the runtime too.
+func hasField(s *types.Struct, name string) bool {
+	for i := 0; i < s.NumFields(); i++ {
+		f := s.Field(i)
+		if f.Name() == name {
+			return true
+		}
+		if es, ok := f.Type().Underlying().(*types.Struct); ok && f.Embedded() && hasField(es, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package a
+
+import "time"
+
+type Name string
+
+type Period struct {
+	Start time.Time
+}
+
+type User struct {
+	Period
+	Name     Name      `validate:"required;minLen=2;alpha"`
+	Typo     string    `validate:"minlen=5"`      // want `validate: unknown rule "minlen", did you mean "minLen"`
+	Age      int       `validate:"email"`         // want `validate: rule "email" does not apply to int fields`
+	Score    *float64  `validate:"min=0.5;max=x"` // want `validate: rule "max" invalid param "x"`
+	Count    uint      `validate:"min=-1"`        // want `validate: rule "min" invalid param "-1"`
+	Code     string    `validate:"match=[a-z"`    // want `validate: rule "match" invalid regexp`
+	Roles    []string  `validate:"in=admin,user"`
+	IDs      []int     `validate:"include=1,x"` // want `validate: rule "include" invalid param "1,x"`
+	Email    string    `validate:"email=true"`  // want `validate: rule "email" does not take a param`
+	Size     string    `validate:"maxLen"`      // want `validate: rule "maxLen" expects a param`
+	End      time.Time `validate:"gtField=Start"`
+	Deadline time.Time `validate:"gtField=Begin"`  // want `validate: rule "gtField" refers to the unknown field "Begin"`
+	Tags     []string  `validate:"required;;bail"` // want `validate: empty rule in tag`
+	Bio      string    `mod:"stripTags;nfc"`
+	Slug     string    `mod:"trim;Lower"`      // want `validate: unknown modifier "Lower", did you mean "lower"`
+	Note     string    `sanitize:"trim;strip"` // want `validate: unknown modifier "strip"`
+}
@@ -0,0 +1,267 @@
+package validator
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	neturl "net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxFormMemory = 32 << 20
+
+// timeLayouts are the layouts accepted for time.Time fields, the last ones are
+// used by the date and datetime-local inputs.
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02"}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Bind sets the fields of the struct pointed to by dst from values and validates
+// it. The fields are named by their query or form tag, their json name otherwise,
+// the values which cannot be converted are reported as type violations. The bools
+// accept on, sent by the checked checkboxes, and are set to false when missing
+// since the unchecked ones are not sent.
+func Bind(values neturl.Values, dst any) error {
+	return defaultValidator.Bind(values, dst)
+}
+
+// BindQuery binds the query string of r, see Bind.
+func BindQuery(r *http.Request, dst any) error {
+	return defaultValidator.BindQuery(r, dst)
+}
+
+// BindForm binds the url encoded or multipart form of r and its query string,
+// see Bind.
+func BindForm(r *http.Request, dst any) error {
+	return defaultValidator.BindForm(r, dst)
+}
+
+func (v *Validator) Bind(values neturl.Values, dst any) error {
+	return v.bind(values, dst, "query", "form")
+}
+
+func (v *Validator) BindQuery(r *http.Request, dst any) error {
+	return v.bind(r.URL.Query(), dst, "query", "form")
+}
+
+func (v *Validator) BindForm(r *http.Request, dst any) error {
+	var err error
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		err = r.ParseMultipartForm(maxFormMemory)
+	} else {
+		err = r.ParseForm()
+	}
+	if err != nil {
+		return &DecodeError{Status: http.StatusBadRequest, Err: err}
+	}
+	return v.bind(r.Form, dst, "form", "query")
+}
+
+func (v *Validator) bind(values neturl.Values, dst any, tags ...string) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validate: bind expects a pointer to a struct, got %T", dst))
+	}
+	rv = rv.Elem()
+	e := &Error{}
+	err := v.bindStruct(rv.Type(), rv, func(ft reflect.StructField) (string, []string, bool) {
+		key := bindKey(ft, tags)
+		input, ok := values[key]
+		if !ok && ft.Type.Kind() == reflect.Bool {
+			return key, []string{"false"}, true
+		}
+		return key, input, ok
+	}, false, e, v.scopes(nil, rv.Type(), ""))
+	if err != nil {
+		return err
+	}
+	if len(e.FieldsErrors) > 0 {
+		return e
+	}
+	return nil
+}
+
+// bindStruct sets the fields of rv from the inputs returned by lookup, applies
+// the defaults when enabled and the modifiers, then validates the fields. The
+// fields errors are named by the keys, with raw they hold the inputs instead of
+// the checked values. The error is the one of an unknown modifier.
+func (v *Validator) bindStruct(t reflect.Type, rv reflect.Value, lookup func(reflect.StructField) (key string, input []string, ok bool), raw bool, e *Error, scopes []rulesScope) error {
+	fields := []boundField{}
+	v.bindFields(t, rv, lookup, scopes, &fields)
+	if v.defaults {
+		v.applyDefaults(t, rv, scopes)
+	}
+	if hasModifiers(t) {
+		if err := sanitizePlan(v.plan(t), rv); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range fields {
+		if e.Truncated {
+			return nil
+		}
+		parent := f.parent
+		check := &rulesCheck{
+			structName: f.structName,
+			name:       f.name,
+			bail:       v.bail || hasConstraint(f.constraints, bail),
+			field: func(name string) (reflect.Value, bool) {
+				field := parent.FieldByName(name)
+				return field, field.IsValid()
+			},
+		}
+		fieldError := FieldError{Field: f.key, Struct: f.structName}
+		if f.typeName != "" || raw && f.input != nil {
+			fieldError.Value = strings.Join(f.input, ",")
+		}
+		if f.typeName != "" {
+			check.violations = append(check.violations, Constraint{Tag: typeRule + "=" + f.typeName, Kind: typeRule, Param: f.typeName})
+		}
+		if len(f.constraints) > 0 && !(check.bail && len(check.violations) > 0) {
+			value := check.value(parent.Field(f.index), f.constraints)
+			if fieldError.Value == nil {
+				fieldError.Value = value
+			}
+		}
+		if len(check.violations) == 0 {
+			continue
+		}
+		fieldError.Violations = check.violations
+		v.appendFieldError(e, fieldError, f.name, f.constraints)
+	}
+	return nil
+}
+
+// boundField is a field set by bindFields, typeName is the expected type of the
+// input when it could not be converted.
+type boundField struct {
+	parent      reflect.Value
+	index       int
+	structName  string
+	name        string
+	key         string
+	input       []string
+	typeName    string
+	constraints []Constraint
+}
+
+func (v *Validator) bindFields(t reflect.Type, rv reflect.Value, lookup func(reflect.StructField) (string, []string, bool), scopes []rulesScope, fields *[]boundField) {
+	for i := 0; i < t.NumField(); i++ {
+		ft := t.Field(i)
+		fv := rv.Field(i)
+
+		if ft.Anonymous && ft.Type.Kind() == reflect.Struct {
+			v.bindFields(ft.Type, fv, lookup, v.scopes(scopes, ft.Type, ft.Name), fields)
+			continue
+		}
+
+		key, input, ok := lookup(ft)
+		constraints, _ := v.fieldConstraints(scopes, ft)
+		f := boundField{parent: rv, index: i, structName: t.Name(), name: ft.Name, key: key, input: input, constraints: constraints}
+		if ok && key != "-" && ft.IsExported() {
+			if typeName, ok := setValue(fv, input); !ok {
+				f.typeName = typeName
+			}
+		}
+		*fields = append(*fields, f)
+	}
+}
+
+func bindKey(ft reflect.StructField, tags []string) string {
+	for _, tag := range tags {
+		if name, _, _ := strings.Cut(ft.Tag.Get(tag), ","); name != "" {
+			return name
+		}
+	}
+	return fieldName(ft)
+}
+
+// setValue converts input to the type of fv, it returns the name of the expected
+// type when the conversion fails. The empty values are left unset.
+func setValue(fv reflect.Value, input []string) (string, bool) {
+	t := fv.Type()
+	if t.Kind() == reflect.Slice && t != reflect.TypeOf([]byte(nil)) {
+		slice := reflect.MakeSlice(t, 0, len(input))
+		for _, s := range input {
+			if s == "" {
+				continue
+			}
+			item := reflect.New(t.Elem()).Elem()
+			if typeName, ok := setValue(item, []string{s}); !ok {
+				return typeName, false
+			}
+			slice = reflect.Append(slice, item)
+		}
+		if slice.Len() > 0 {
+			fv.Set(slice)
+		}
+		return "", true
+	}
+	if len(input) == 0 || input[0] == "" && t.Kind() != reflect.String {
+		return "", true
+	}
+	s := input[0]
+	if t.Kind() == reflect.Pointer {
+		p := reflect.New(t.Elem())
+		if typeName, ok := setValue(p.Elem(), input); !ok {
+			return typeName, false
+		}
+		fv.Set(p)
+		return "", true
+	}
+
+	switch {
+	case t == timeType:
+		for _, layout := range timeLayouts {
+			if value, err := time.Parse(layout, s); err == nil {
+				fv.Set(reflect.ValueOf(value))
+				return "", true
+			}
+		}
+		return "time", false
+	case t == durationType:
+		value, err := time.ParseDuration(s)
+		if err != nil {
+			return "duration", false
+		}
+		fv.SetInt(int64(value))
+	case t.Kind() == reflect.String:
+		fv.SetString(s)
+	case t.Kind() == reflect.Slice:
+		fv.SetBytes([]byte(s))
+	case t.Kind() == reflect.Bool:
+		value, err := strconv.ParseBool(s)
+		if s == "on" {
+			value, err = true, nil
+		}
+		if err != nil {
+			return "bool", false
+		}
+		fv.SetBool(value)
+	case isIntKind(t.Kind()):
+		value, err := strconv.ParseInt(s, 10, t.Bits())
+		if err != nil {
+			return "int", false
+		}
+		fv.SetInt(value)
+	case isUintKind(t.Kind()):
+		value, err := strconv.ParseUint(s, 10, t.Bits())
+		if err != nil {
+			return "uint", false
+		}
+		fv.SetUint(value)
+	case isFloatKind(t.Kind()):
+		value, err := strconv.ParseFloat(s, t.Bits())
+		if err != nil {
+			return "float", false
+		}
+		fv.SetFloat(value)
+	default:
+		return t.String(), false
+	}
+	return "", true
+}
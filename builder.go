@@ -0,0 +1,166 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type typeRules struct {
+	fields map[string][]Constraint
+	merge  bool
+}
+
+type rulesScope struct {
+	rules  *typeRules
+	prefix string
+}
+
+// Rules registers code based constraints for the fields of T, they replace the
+// validate tag of the field unless MergeTags is used.
+type Rules[T any] struct {
+	v     *Validator
+	rules *typeRules
+}
+
+func For[T any]() *Rules[T] {
+	return ForValidator[T](defaultValidator)
+}
+
+func ForValidator[T any](v *Validator) *Rules[T] {
+	return &Rules[T]{
+		v:     v,
+		rules: v.typeRules(reflect.TypeOf((*T)(nil)).Elem()),
+	}
+}
+
+// Field adds constraints to the field returned by selector, which must be a
+// func(*T) *F returning the address of a field of T, e.g.
+//
+//	func(u *User) *string { return &u.Name }
+func (r *Rules[T]) Field(selector any, constraints ...Constraint) *Rules[T] {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	path := selectField(t, selector)
+	r.v.mu.Lock()
+	defer r.v.mu.Unlock()
+	r.rules.fields[path] = append(r.rules.fields[path], constraints...)
+	return r
+}
+
+// MergeTags appends the registered constraints to the validate tags instead of replacing them.
+func (r *Rules[T]) MergeTags() *Rules[T] {
+	r.v.mu.Lock()
+	defer r.v.mu.Unlock()
+	r.rules.merge = true
+	return r
+}
+
+func (v *Validator) typeRules(t reflect.Type) *typeRules {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	rules, ok := v.types[t]
+	if !ok {
+		rules = &typeRules{fields: map[string][]Constraint{}}
+		v.types[t] = rules
+	}
+	return rules
+}
+
+// scopes returns the rules visible from the fields of t, the rules of the
+// outer structs come first so they win over the rules of the embedded ones.
+func (v *Validator) scopes(outer []rulesScope, t reflect.Type, name string) []rulesScope {
+	scopes := []rulesScope{}
+	for _, scope := range outer {
+		scopes = append(scopes, rulesScope{rules: scope.rules, prefix: scope.prefix + name + "."})
+	}
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if rules, ok := v.types[t]; ok {
+		scopes = append(scopes, rulesScope{rules: rules})
+	}
+	return scopes
+}
+
+func (v *Validator) fieldConstraints(scopes []rulesScope, ft reflect.StructField) ([]Constraint, bool) {
+	constraints := []Constraint{}
+	tag, ok := ft.Tag.Lookup("validate")
+	if ok {
+		constraints = parseConstraints(tag)
+	}
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	for _, scope := range scopes {
+		if cs, found := scope.rules.fields[scope.prefix+ft.Name]; found {
+			if scope.rules.merge {
+				return append(constraints, cs...), true
+			}
+			return cs, true
+		}
+	}
+	return constraints, ok
+}
+
+func selectField(t reflect.Type, selector any) string {
+	sv := reflect.ValueOf(selector)
+	st := sv.Type()
+	if st.Kind() != reflect.Func || st.NumIn() != 1 || st.In(0) != reflect.PointerTo(t) || st.NumOut() != 1 || st.Out(0).Kind() != reflect.Pointer {
+		panic(fmt.Sprintf("validate: struct %s invalid field selector %s", t.Name(), st))
+	}
+	base := reflect.New(t)
+	field := sv.Call([]reflect.Value{base})[0]
+	if path, ok := fieldPath(t, base.Pointer(), field.Pointer(), st.Out(0).Elem()); ok {
+		return path
+	}
+	panic(fmt.Sprintf("validate: struct %s selector %s does not return one of its fields", t.Name(), st))
+}
+
+func fieldPath(t reflect.Type, base, target uintptr, ft reflect.Type) (string, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if base+f.Offset == target && f.Type == ft {
+			return f.Name, true
+		}
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			if path, ok := fieldPath(f.Type, base+f.Offset, target, ft); ok {
+				return f.Name + "." + path, true
+			}
+		}
+	}
+	return "", false
+}
+
+// RegisterStructRules attaches validate tags to the fields of the type of s, the
+// keys are the go field names, fields of embedded structs are prefixed by the
+// embedded type name, e.g. "BaseModel.ID".
+func RegisterStructRules(s any, tags map[string]string) {
+	defaultValidator.RegisterStructRules(s, tags)
+}
+
+func (v *Validator) RegisterStructRules(s any, tags map[string]string) {
+	t := indirect(reflect.TypeOf(s))
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validate: %s is not a struct", t))
+	}
+	for name := range tags {
+		if !hasFieldPath(t, strings.Split(name, ".")) {
+			panic(fmt.Sprintf("validate: struct %s has no field %s", t.Name(), name))
+		}
+	}
+	rules := v.typeRules(t)
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for name, tag := range tags {
+		rules.fields[name] = parseConstraints(tag)
+	}
+}
+
+func hasFieldPath(t reflect.Type, path []string) bool {
+	f, ok := t.FieldByName(path[0])
+	if !ok || len(f.Index) != 1 {
+		return false
+	}
+	if len(path) == 1 {
+		return true
+	}
+	return f.Anonymous && f.Type.Kind() == reflect.Struct && hasFieldPath(f.Type, path[1:])
+}
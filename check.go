@@ -0,0 +1,284 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+type rulesCheck struct {
+	structName string
+	name       string
+	bail       bool
+	field      func(name string) (reflect.Value, bool)
+	violations []Constraint
+}
+
+// fail records a violation and reports whether the remaining rules must be skipped.
+func (c *rulesCheck) fail(constraint Constraint) bool {
+	c.violations = append(c.violations, constraint)
+	return c.bail
+}
+
+func (c *rulesCheck) invalidParam(constraint Constraint) {
+	location := "var"
+	if c.structName != "" {
+		location = fmt.Sprintf("struct %s field %s", c.structName, c.name)
+	} else if c.name != "" {
+		location = "field " + c.name
+	}
+	panic(fmt.Sprintf("validate: %s tag %s invalid param %v", location, constraint.Tag, constraint.Param))
+}
+
+// value runs the constraints against fv and returns the checked value.
+func (c *rulesCheck) value(fv reflect.Value, constraints []Constraint) any {
+	if !fv.IsValid() {
+		if hasConstraint(constraints, required) {
+			c.fail(Constraint{Tag: required, Kind: required})
+		}
+		return nil
+	}
+	value := c.kindValue(fv, constraints)
+	if c.bail && len(c.violations) > 0 {
+		return value
+	}
+	c.checkFields(fv, constraints)
+	return value
+}
+
+func (c *rulesCheck) kindValue(fv reflect.Value, constraints []Constraint) any {
+	t := fv.Type()
+	if isMissing(fv) {
+		if hasConstraint(constraints, required) {
+			c.fail(Constraint{Tag: required, Kind: required})
+			return nil
+		}
+		if fv.Kind() == reflect.Pointer || fv.Kind() == reflect.Slice {
+			return nil
+		}
+	}
+
+	switch {
+	case isString(t):
+		value, _ := getStringValue(fv)
+		c.checkString(value, constraints)
+		return value
+	case isInt(t):
+		value, _ := getIntValue(fv)
+		checkNumber(c, value, constraints, getIntParam)
+		return value
+	case isUint(t):
+		value, _ := getUintValue(fv)
+		checkNumber(c, value, constraints, getUintParam)
+		return value
+	case isFloat(t):
+		value, _ := getFloatValue(fv)
+		checkNumber(c, value, constraints, getFloatParam)
+		return value
+	case isStringArray(t):
+		value, _ := getStringArrayValue(fv)
+		checkList(c, value, constraints, getStringListParam)
+		return value
+	case isIntArray(t):
+		value, _ := getIntArrayValue(fv)
+		checkList(c, value, constraints, getIntListParam)
+		return value
+	case isUintArray(t):
+		value, _ := getUintArrayValue(fv)
+		checkList(c, value, constraints, getUintListParam)
+		return value
+	case isFloatArray(t):
+		value, _ := getFloatArrayValue(fv)
+		checkList(c, value, constraints, getFloatListParam)
+		return value
+	}
+	return nil
+}
+
+func (c *rulesCheck) checkString(value string, constraints []Constraint) {
+	for _, constraint := range constraints {
+		if exp, ok := regexMap[constraint.Kind]; ok {
+			if !exp.MatchString(value) && c.fail(constraint) {
+				return
+			}
+			continue
+		}
+		switch constraint.Kind {
+		case minLen, maxLen, length:
+			param, ok := getIntParam(constraint.Param)
+			if !ok {
+				c.invalidParam(constraint)
+			}
+			constraint.Param = param
+			l := int64(len(value))
+			if (constraint.Kind == minLen && l < param ||
+				constraint.Kind == maxLen && l > param ||
+				constraint.Kind == length && l != param) && c.fail(constraint) {
+				return
+			}
+		case in, oneOf, out:
+			param := getOneOfString(constraint.Param)
+			if param == nil {
+				c.invalidParam(constraint)
+			}
+			constraint.Param = param
+			if inArray(param, value) == (constraint.Kind == out) && c.fail(constraint) {
+				return
+			}
+		case match:
+			param, ok := getStringParam(constraint.Param)
+			if !ok {
+				c.invalidParam(constraint)
+			}
+			exp, err := compileMatch(param)
+			if err != nil {
+				c.invalidParam(constraint)
+			}
+			if !exp.MatchString(value) && c.fail(constraint) {
+				return
+			}
+		}
+	}
+}
+
+func checkNumber[T int64 | uint64 | float64](c *rulesCheck, value T, constraints []Constraint, getParam func(any) (T, bool)) {
+	for _, constraint := range constraints {
+		if constraint.Kind != min && constraint.Kind != max {
+			continue
+		}
+		param, ok := getParam(constraint.Param)
+		if !ok {
+			c.invalidParam(constraint)
+		}
+		constraint.Param = param
+		if (constraint.Kind == min && value < param || constraint.Kind == max && value > param) && c.fail(constraint) {
+			return
+		}
+	}
+}
+
+func checkList[T comparable](c *rulesCheck, value []T, constraints []Constraint, getParam func(any) ([]T, bool)) {
+	for _, constraint := range constraints {
+		var valid bool
+		switch constraint.Kind {
+		case in, out, include, exclude:
+		default:
+			continue
+		}
+		param, ok := getParam(constraint.Param)
+		if !ok {
+			c.invalidParam(constraint)
+		}
+		constraint.Param = param
+		switch constraint.Kind {
+		case in:
+			valid = insArray(param, value)
+		case out:
+			valid = outsArray(param, value)
+		case include:
+			valid = insArray(value, param)
+		case exclude:
+			valid = outsArray(value, param)
+		}
+		if !valid && c.fail(constraint) {
+			return
+		}
+	}
+}
+
+var fieldComparisons = map[string]func(int) bool{
+	eqField:  func(cmp int) bool { return cmp == 0 },
+	neField:  func(cmp int) bool { return cmp != 0 },
+	gtField:  func(cmp int) bool { return cmp > 0 },
+	gteField: func(cmp int) bool { return cmp >= 0 },
+	ltField:  func(cmp int) bool { return cmp < 0 },
+	lteField: func(cmp int) bool { return cmp <= 0 },
+}
+
+func (c *rulesCheck) checkFields(fv reflect.Value, constraints []Constraint) {
+	for _, constraint := range constraints {
+		op, ok := fieldComparisons[constraint.Kind]
+		if !ok {
+			continue
+		}
+		name, _ := getStringParam(constraint.Param)
+		var other reflect.Value
+		if c.field != nil {
+			other, ok = c.field(name)
+		}
+		if !ok {
+			c.invalidParam(constraint)
+		}
+		a, ok := elem(fv)
+		if !ok {
+			continue
+		}
+		b, ok := elem(other)
+		if !ok || !b.IsValid() {
+			continue
+		}
+		cmp, ok := compareValues(a, b)
+		if !ok {
+			c.invalidParam(constraint)
+		}
+		if !op(cmp) && c.fail(constraint) {
+			return
+		}
+	}
+}
+
+func compareValues(a, b reflect.Value) (int, bool) {
+	if ta, ok := a.Interface().(time.Time); ok {
+		if tb, ok := b.Interface().(time.Time); ok {
+			return ta.Compare(tb), true
+		}
+		return 0, false
+	}
+	switch {
+	case a.Kind() == reflect.String && b.Kind() == reflect.String:
+		return compare(a.String(), b.String()), true
+	case a.Kind() == reflect.Bool && b.Kind() == reflect.Bool:
+		if a.Bool() == b.Bool() {
+			return 0, true
+		}
+		if b.Bool() {
+			return -1, true
+		}
+		return 1, true
+	case isIntKind(a.Kind()) && isIntKind(b.Kind()):
+		return compare(a.Int(), b.Int()), true
+	case isUintKind(a.Kind()) && isUintKind(b.Kind()):
+		return compare(a.Uint(), b.Uint()), true
+	}
+	fa, ok := getNumber(a)
+	if !ok {
+		return 0, false
+	}
+	fb, ok := getNumber(b)
+	if !ok {
+		return 0, false
+	}
+	return compare(fa, fb), true
+}
+
+func getNumber(v reflect.Value) (float64, bool) {
+	switch {
+	case isIntKind(v.Kind()):
+		return float64(v.Int()), true
+	case isUintKind(v.Kind()):
+		return float64(v.Uint()), true
+	case isFloatKind(v.Kind()):
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+func compare[T int64 | uint64 | float64 | string](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
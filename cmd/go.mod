@@ -0,0 +1,19 @@
+module github.com/oSethoum/validator/cmd
+
+go 1.22.0
+
+require (
+	github.com/oSethoum/validator v0.0.0-00010101000000-000000000000
+	github.com/oSethoum/validator/analyzer v0.0.0-00010101000000-000000000000
+	golang.org/x/tools v0.30.0
+)
+
+require (
+	golang.org/x/mod v0.23.0 // indirect
+	golang.org/x/sync v0.11.0 // indirect
+)
+
+replace (
+	github.com/oSethoum/validator => ../
+	github.com/oSethoum/validator/analyzer => ../analyzer
+)
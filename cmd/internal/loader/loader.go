@@ -0,0 +1,149 @@
+package loader
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+type Package struct {
+	*packages.Package
+	// Structs are the exported struct types with at least one validate tag.
+	Structs []*types.TypeName
+}
+
+func Load(patterns ...string) ([]*Package, error) {
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedTypes | packages.NeedTypesSizes | packages.NeedSyntax | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps | packages.NeedModule,
+	}, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	result := []*Package{}
+	for _, pkg := range pkgs {
+		for _, err := range pkg.Errors {
+			// stale generated validators must not prevent their regeneration
+			if !strings.Contains(err.Pos, "_validator.go:") {
+				return nil, err
+			}
+		}
+		p := &Package{Package: pkg}
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			obj, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || !obj.Exported() || obj.IsAlias() {
+				continue
+			}
+			if named, ok := obj.Type().(*types.Named); ok && named.TypeParams().Len() > 0 {
+				continue
+			}
+			if s, ok := obj.Type().Underlying().(*types.Struct); ok && HasValidateTags(s) {
+				p.Structs = append(p.Structs, obj)
+			}
+		}
+		sort.Slice(p.Structs, func(i, j int) bool { return p.Structs[i].Pos() < p.Structs[j].Pos() })
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+func HasValidateTags(s *types.Struct) bool {
+	for i := 0; i < s.NumFields(); i++ {
+		if _, ok := reflect.StructTag(s.Tag(i)).Lookup("validate"); ok {
+			return true
+		}
+		if f := s.Field(i); f.Embedded() {
+			if es, ok := f.Type().Underlying().(*types.Struct); ok && HasValidateTags(es) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Run compiles and runs a main package importing the given packages within the
+// module of dir, so that the runtime reflection based APIs can be used on them.
+// The imports map the package paths to their import names. The package is
+// written to a temporary directory and overlaid on the module, which is left
+// untouched.
+func Run(dir string, imports map[string]string, body string, args ...string) ([]byte, error) {
+	module, err := moduleRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+	tmp, err := os.MkdirTemp("", "validator")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	src := strings.Builder{}
+	src.WriteString("package main\n\nimport (\n")
+	paths := []string{}
+	for path := range imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		fmt.Fprintf(&src, "\t%s %q\n", imports[path], path)
+	}
+	fmt.Fprintf(&src, ")\n\nfunc main() {\n%s\n}\n", body)
+	mainFile := filepath.Join(tmp, "main.go")
+	if err := os.WriteFile(mainFile, []byte(src.String()), 0o644); err != nil {
+		return nil, err
+	}
+	// the directory of the package only exists in the overlay
+	pkg := "." + filepath.Base(tmp)
+	overlay, err := json.Marshal(map[string]any{
+		"Replace": map[string]string{filepath.Join(module, pkg, "main.go"): mainFile},
+	})
+	if err != nil {
+		return nil, err
+	}
+	overlayFile := filepath.Join(tmp, "overlay.json")
+	if err := os.WriteFile(overlayFile, overlay, 0o644); err != nil {
+		return nil, err
+	}
+
+	stderr := &strings.Builder{}
+	cmd := exec.Command("go", append([]string{"run", "-overlay", overlayFile, "./" + pkg}, args...)...)
+	cmd.Dir = module
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		// go run reports the exit status of the program on its last line
+		if i := strings.LastIndex(msg, "\nexit status "); i >= 0 {
+			msg = msg[:i]
+		}
+		if msg == "" {
+			return out, err
+		}
+		return out, errors.New(msg)
+	}
+	return out, nil
+}
+
+func moduleRoot(dir string) (string, error) {
+	out, err := exec.Command("go", "env", "-C", dir, "GOMOD").Output()
+	if err != nil {
+		return "", err
+	}
+	mod := strings.TrimSpace(string(out))
+	if mod == "" || mod == os.DevNull {
+		return "", errors.New("loader: " + dir + " is not within a module")
+	}
+	return filepath.Dir(mod), nil
+}
@@ -0,0 +1,10 @@
+package main
+
+import (
+	"github.com/oSethoum/validator/analyzer"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(analyzer.Analyzer)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/oSethoum/validator/cmd/internal/loader"
+)
+
+// checkMain decodes the file given as first argument into value and prints the
+// result of its validation, it exits with the status 1 when the value is invalid.
+const checkMain = `	data, err := os.ReadFile(os.Args[1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(value); err != nil {
+		fmt.Fprintf(os.Stderr, "%%s: %%v\n", os.Args[1], err)
+		os.Exit(2)
+	}
+	err = validator.Struct(value)
+	verr, _ := err.(*validator.Error)
+	if err != nil && verr == nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if %t {
+		out, _ := json.MarshalIndent(struct {
+			Valid bool ` + "`json:\"valid\"`" + `
+			*validator.Error
+		}{verr == nil, verr}, "", "  ")
+		fmt.Println(string(out))
+	} else if verr == nil {
+		fmt.Println("valid")
+	} else {
+		for _, fieldError := range verr.FieldsErrors {
+			for _, violation := range fieldError.Violations {
+				fmt.Printf("%%s: %%s (%%s)\n", fieldError.Field, violation.Message(), violation.Tag)
+			}
+		}
+		if verr.Truncated {
+			fmt.Println("(truncated)")
+		}
+	}
+	if verr != nil {
+		os.Exit(1)
+	}`
+
+func check(args []string) error {
+	fs := flag.NewFlagSet("check", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "print the result as JSON")
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		return fmt.Errorf("usage: validator check [-json] pkg.Type file.json")
+	}
+	pkg, obj, err := lookup(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	file, err := filepath.Abs(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	imports := map[string]string{
+		"bytes":                         "bytes",
+		"encoding/json":                 "json",
+		"fmt":                           "fmt",
+		"os":                            "os",
+		"github.com/oSethoum/validator": "validator",
+		pkg.PkgPath:                     "p",
+	}
+	body := fmt.Sprintf("\tvalue := new(p.%s)\n", obj.Name()) + fmt.Sprintf(checkMain, *jsonOutput)
+	out, err := loader.Run(pkg.Dir, imports, body, file)
+	os.Stdout.Write(out)
+	if err != nil && len(out) > 0 {
+		// the result is printed, the value is invalid
+		return errInvalid
+	}
+	return err
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/types"
+	"os"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/oSethoum/validator"
+)
+
+func explain(args []string) error {
+	fs := flag.NewFlagSet("explain", flag.ExitOnError)
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: validator explain pkg.Type")
+	}
+	pkg, obj, err := lookup(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "FIELD\tTYPE\tRULE\tMEANING")
+	qualifier := types.RelativeTo(pkg.Types)
+	explainFields(w, obj.Type().Underlying().(*types.Struct), qualifier)
+	return w.Flush()
+}
+
+func explainFields(w *tabwriter.Writer, s *types.Struct, qualifier types.Qualifier) {
+	for i := 0; i < s.NumFields(); i++ {
+		f := s.Field(i)
+		if !f.Exported() {
+			continue
+		}
+		tag := reflect.StructTag(s.Tag(i))
+		name, _, _ := strings.Cut(tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if es, ok := f.Type().Underlying().(*types.Struct); ok && f.Embedded() && name == "" {
+			explainFields(w, es, qualifier)
+			continue
+		}
+		if name == "" {
+			name = f.Name()
+		}
+		typeName := types.TypeString(f.Type(), qualifier)
+		rules, ok := tag.Lookup("validate")
+		if !ok || rules == "" {
+			fmt.Fprintf(w, "%s\t%s\t-\tno rules\n", name, typeName)
+			continue
+		}
+		constraints := validator.ParseTag(rules)
+		for _, constraint := range constraints {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", name, typeName, constraint.Tag, constraint.Message())
+			name, typeName = "", ""
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/types"
+	"os"
+	"strings"
+
+	"github.com/oSethoum/validator/analyzer"
+	"github.com/oSethoum/validator/cmd/internal/loader"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+const usage = `usage: validator <command> [flags] [arguments]
+
+commands:
+  lint [packages]              report the invalid validate tags
+  explain pkg.Type             print the rules of each field of the type
+  check [-json] pkg.Type file  validate the JSON file decoded into the type
+`
+
+// errInvalid is returned by the commands which already reported the problems.
+var errInvalid = fmt.Errorf("invalid")
+
+func main() {
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch flag.Arg(0) {
+	case "lint":
+		err = lint(flag.Args()[1:])
+	case "explain":
+		err = explain(flag.Args()[1:])
+	case "check":
+		err = check(flag.Args()[1:])
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err == errInvalid {
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "validator:", err)
+		os.Exit(1)
+	}
+}
+
+func lint(args []string) error {
+	pkgs, err := loader.Load(args...)
+	if err != nil {
+		return err
+	}
+	roots := []*packages.Package{}
+	for _, pkg := range pkgs {
+		roots = append(roots, pkg.Package)
+	}
+	graph, err := checker.Analyze([]*analysis.Analyzer{analyzer.Analyzer}, roots, nil)
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, action := range graph.Roots {
+		if len(action.Diagnostics) > 0 {
+			found = true
+		}
+	}
+	if err := graph.PrintText(os.Stderr, -1); err != nil {
+		return err
+	}
+	if found {
+		return errInvalid
+	}
+	return nil
+}
+
+// lookup loads the struct type named by arg, a package pattern followed by the
+// type name such as ./models.User or example.com/app/models.User.
+func lookup(arg string) (*loader.Package, *types.TypeName, error) {
+	i := strings.LastIndex(arg, ".")
+	if i <= strings.LastIndex(arg, "/") {
+		return nil, nil, fmt.Errorf("%s: expected pkg.Type", arg)
+	}
+	pattern, name := arg[:i], arg[i+1:]
+	if pattern == "" {
+		pattern = "."
+	}
+	pkgs, err := loader.Load(pattern)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, nil, fmt.Errorf("%s: matched %d packages", pattern, len(pkgs))
+	}
+	obj, ok := pkgs[0].Types.Scope().Lookup(name).(*types.TypeName)
+	if !ok || !obj.Exported() {
+		return nil, nil, fmt.Errorf("%s: no exported type %s in %s", arg, name, pkgs[0].PkgPath)
+	}
+	if _, ok := obj.Type().Underlying().(*types.Struct); !ok {
+		return nil, nil, fmt.Errorf("%s: not a struct type", arg)
+	}
+	return pkgs[0], obj, nil
+}
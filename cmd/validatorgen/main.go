@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/oSethoum/validator/cmd/internal/loader"
+)
+
+const usage = `usage: validatorgen <command> [flags] [packages]
+
+commands:
+  validate  write a Validate method per validated struct in *_validator.go files
+  zod       write the zod schemas of the validated structs
+`
+
+func main() {
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch flag.Arg(0) {
+	case "validate":
+		err = validate(flag.Args()[1:])
+	case "zod":
+		err = zod(flag.Args()[1:])
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "validatorgen:", err)
+		os.Exit(1)
+	}
+}
+
+func zod(args []string) error {
+	fs := flag.NewFlagSet("zod", flag.ExitOnError)
+	output := fs.String("o", "", "output file, defaults to stdout")
+	fs.Parse(args)
+
+	pkgs, err := loader.Load(fs.Args()...)
+	if err != nil {
+		return err
+	}
+	imports := map[string]string{"github.com/oSethoum/validator": "validator", "os": "os"}
+	values := []string{}
+	dir := ""
+	for i, pkg := range pkgs {
+		if len(pkg.Structs) == 0 {
+			continue
+		}
+		name := fmt.Sprintf("p%d", i)
+		imports[pkg.PkgPath] = name
+		for _, s := range pkg.Structs {
+			values = append(values, fmt.Sprintf("new(%s.%s)", name, s.Name()))
+		}
+		if dir == "" && len(pkg.GoFiles) > 0 {
+			dir = pkg.Dir
+		}
+	}
+	if len(values) == 0 {
+		return fmt.Errorf("no validated struct found")
+	}
+
+	out, err := loader.Run(dir, imports, fmt.Sprintf("\tos.Stdout.WriteString(validator.Zod(%s))", strings.Join(values, ", ")))
+	if err != nil {
+		return err
+	}
+	if *output == "" {
+		_, err = os.Stdout.Write(out)
+		return err
+	}
+	return os.WriteFile(*output, out, 0o644)
+}
@@ -0,0 +1,33 @@
+// Package signup holds the structs of the golden test of validatorgen, its
+// signup_validator.go file is the expected output.
+package signup
+
+import "time"
+
+type Role string
+
+type Base struct {
+	ID      string    `json:"id" validate:"required;len=4"`
+	Created time.Time `json:"created" validate:"required"`
+}
+
+type Signup struct {
+	Base
+	Name     string            `json:"name" validate:"minLen=5;alpha"`
+	Email    string            `json:"email" validate:"bail;email;minLen=10"`
+	Age      int               `json:"age" validate:"required;min=18;max=130"`
+	Score    *float64          `json:"score" validate:"min=0;max=0.5"`
+	Credits  uint8             `json:"credits" validate:"max=200"`
+	Role     Role              `json:"role" validate:"in=admin, user"`
+	Code     *string           `json:"code" validate:"match=^[a-z]{3}-\\d+$"`
+	Roles    []Role            `json:"roles" validate:"in=admin,user;include=user"`
+	Codes    []int             `json:"codes" validate:"out=0;exclude=13"`
+	Password string            `json:"password" validate:"required;minLen=8;sensitive"`
+	Confirm  string            `json:"confirm" validate:"eqField=Password;sensitive"`
+	Start    time.Time         `json:"start"`
+	End      *time.Time        `json:"end" validate:"gtField=Start"`
+	Min      int               `json:"min"`
+	Limit    uint              `json:"limit" validate:"gteField=Min"`
+	Accepted bool              `json:"accepted" validate:"required"`
+	Labels   map[string]string `json:"labels" validate:"required"`
+}
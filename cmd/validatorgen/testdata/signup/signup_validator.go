@@ -0,0 +1,238 @@
+// Code generated by validatorgen. DO NOT EDIT.
+
+package signup
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/oSethoum/validator"
+)
+
+var (
+	validatorBaseID = []validator.Constraint{
+		{Tag: "required", Kind: "required"},
+		{Tag: "len=4", Kind: "len", Param: int64(4)},
+	}
+	validatorBaseCreated = []validator.Constraint{
+		{Tag: "required", Kind: "required"},
+	}
+	validatorSignupBaseID = []validator.Constraint{
+		{Tag: "required", Kind: "required"},
+		{Tag: "len=4", Kind: "len", Param: int64(4)},
+	}
+	validatorSignupBaseCreated = []validator.Constraint{
+		{Tag: "required", Kind: "required"},
+	}
+	validatorSignupNameRegexp1 = validator.RuleRegexp("alpha")
+	validatorSignupName        = []validator.Constraint{
+		{Tag: "minLen=5", Kind: "minLen", Param: int64(5)},
+		{Tag: "alpha", Kind: "alpha"},
+	}
+	validatorSignupEmailRegexp1 = validator.RuleRegexp("email")
+	validatorSignupEmail        = []validator.Constraint{
+		{Tag: "email", Kind: "email"},
+		{Tag: "minLen=10", Kind: "minLen", Param: int64(10)},
+		{Tag: "bail", Kind: "bail"},
+	}
+	validatorSignupAge = []validator.Constraint{
+		{Tag: "required", Kind: "required"},
+		{Tag: "min=18", Kind: "min", Param: int64(18)},
+		{Tag: "max=130", Kind: "max", Param: int64(130)},
+	}
+	validatorSignupScore = []validator.Constraint{
+		{Tag: "min=0", Kind: "min", Param: float64(0)},
+		{Tag: "max=0.5", Kind: "max", Param: float64(0.5)},
+	}
+	validatorSignupCredits = []validator.Constraint{
+		{Tag: "max=200", Kind: "max", Param: uint64(200)},
+	}
+	validatorSignupRole = []validator.Constraint{
+		{Tag: "in=admin, user", Kind: "in", Param: []string{"admin", "user"}},
+	}
+	validatorSignupCodeRegexp0 = regexp.MustCompile("^[a-z]{3}-\\d+$")
+	validatorSignupCode        = []validator.Constraint{
+		{Tag: "match=^[a-z]{3}-\\d+$", Kind: "match", Param: "^[a-z]{3}-\\d+$"},
+	}
+	validatorSignupRoles = []validator.Constraint{
+		{Tag: "in=admin,user", Kind: "in", Param: []string{"admin", "user"}},
+		{Tag: "include=user", Kind: "include", Param: []string{"user"}},
+	}
+	validatorSignupCodes = []validator.Constraint{
+		{Tag: "out=0", Kind: "out", Param: []int64{0}},
+		{Tag: "exclude=13", Kind: "exclude", Param: []int64{13}},
+	}
+	validatorSignupPassword = []validator.Constraint{
+		{Tag: "required", Kind: "required"},
+		{Tag: "minLen=8", Kind: "minLen", Param: int64(8)},
+		{Tag: "sensitive", Kind: "sensitive"},
+	}
+	validatorSignupConfirm = []validator.Constraint{
+		{Tag: "eqField=Password", Kind: "eqField", Param: "Password"},
+		{Tag: "sensitive", Kind: "sensitive"},
+	}
+	validatorSignupEnd = []validator.Constraint{
+		{Tag: "gtField=Start", Kind: "gtField", Param: "Start"},
+	}
+	validatorSignupLimit = []validator.Constraint{
+		{Tag: "gteField=Min", Kind: "gteField", Param: "Min"},
+	}
+	validatorSignupAccepted = []validator.Constraint{
+		{Tag: "required", Kind: "required"},
+	}
+	validatorSignupLabels = validator.ParseTag("required")
+)
+
+func init() {
+	validator.RegisterGenerated((*Base).ValidateWith)
+	validator.RegisterGenerated((*Signup).ValidateWith)
+}
+
+func (x *Base) Validate() error {
+	return x.ValidateWith(validator.Default())
+}
+
+func (x *Base) ValidateWith(v *validator.Validator) error {
+	c := v.NewCheck()
+	if failed := [...]bool{
+		x.ID == "",
+		x.ID != "" && int64(len(x.ID)) != 4,
+	}; failed != [2]bool{} && c.Fail("Base", "ID", "id", validatorBaseID, x.ID, failed[:]...) {
+		return c.Err()
+	}
+	if failed := [...]bool{
+		x.Created == (time.Time{}),
+	}; failed != [1]bool{} && c.Fail("Base", "Created", "created", validatorBaseCreated, x.Created, failed[:]...) {
+		return c.Err()
+	}
+	return c.Err()
+}
+
+func (x *Signup) Validate() error {
+	return x.ValidateWith(validator.Default())
+}
+
+func (x *Signup) ValidateWith(v *validator.Validator) error {
+	c := v.NewCheck()
+	if failed := [...]bool{
+		x.Base.ID == "",
+		x.Base.ID != "" && int64(len(x.Base.ID)) != 4,
+	}; failed != [2]bool{} && c.Fail("Base", "ID", "id", validatorSignupBaseID, x.Base.ID, failed[:]...) {
+		return c.Err()
+	}
+	if failed := [...]bool{
+		x.Base.Created == (time.Time{}),
+	}; failed != [1]bool{} && c.Fail("Base", "Created", "created", validatorSignupBaseCreated, x.Base.Created, failed[:]...) {
+		return c.Err()
+	}
+	if failed := [...]bool{
+		int64(len(x.Name)) < 5,
+		!validatorSignupNameRegexp1.MatchString(string(x.Name)),
+	}; failed != [2]bool{} && c.Fail("Signup", "Name", "name", validatorSignupName, x.Name, failed[:]...) {
+		return c.Err()
+	}
+	if failed := [...]bool{
+		!validatorSignupEmailRegexp1.MatchString(string(x.Email)),
+		int64(len(x.Email)) < 10,
+	}; failed != [2]bool{} && c.Fail("Signup", "Email", "email", validatorSignupEmail, x.Email, failed[:]...) {
+		return c.Err()
+	}
+	if failed := [...]bool{
+		x.Age == 0,
+		x.Age != 0 && int64(x.Age) < 18,
+		x.Age != 0 && int64(x.Age) > 130,
+	}; failed != [3]bool{} && c.Fail("Signup", "Age", "age", validatorSignupAge, x.Age, failed[:]...) {
+		return c.Err()
+	}
+	if failed := [...]bool{
+		x.Score != nil && float64(*x.Score) < 0,
+		x.Score != nil && float64(*x.Score) > 0.5,
+	}; failed != [2]bool{} && c.Fail("Signup", "Score", "score", validatorSignupScore, x.Score, failed[:]...) {
+		return c.Err()
+	}
+	if failed := [...]bool{
+		uint64(x.Credits) > 200,
+	}; failed != [1]bool{} && c.Fail("Signup", "Credits", "credits", validatorSignupCredits, x.Credits, failed[:]...) {
+		return c.Err()
+	}
+	if failed := [...]bool{
+		!(string(x.Role) == "admin" || string(x.Role) == "user"),
+	}; failed != [1]bool{} && c.Fail("Signup", "Role", "role", validatorSignupRole, x.Role, failed[:]...) {
+		return c.Err()
+	}
+	if failed := [...]bool{
+		x.Code != nil && !validatorSignupCodeRegexp0.MatchString(string(*x.Code)),
+	}; failed != [1]bool{} && c.Fail("Signup", "Code", "code", validatorSignupCode, x.Code, failed[:]...) {
+		return c.Err()
+	}
+	if failed := [...]bool{
+		x.Roles != nil && (func() bool {
+			for _, v := range x.Roles {
+				if !(string(v) == "admin" || string(v) == "user") {
+					return true
+				}
+			}
+			return false
+		}()),
+		x.Roles != nil && !func() bool {
+			for _, v := range x.Roles {
+				if string(v) == "user" {
+					return true
+				}
+			}
+			return false
+		}(),
+	}; failed != [2]bool{} && c.Fail("Signup", "Roles", "roles", validatorSignupRoles, x.Roles, failed[:]...) {
+		return c.Err()
+	}
+	if failed := [...]bool{
+		x.Codes != nil && func() bool {
+			for _, v := range x.Codes {
+				if int64(v) == 0 {
+					return true
+				}
+			}
+			return false
+		}(),
+		x.Codes != nil && func() bool {
+			for _, v := range x.Codes {
+				if int64(v) == 13 {
+					return true
+				}
+			}
+			return false
+		}(),
+	}; failed != [2]bool{} && c.Fail("Signup", "Codes", "codes", validatorSignupCodes, x.Codes, failed[:]...) {
+		return c.Err()
+	}
+	if failed := [...]bool{
+		x.Password == "",
+		x.Password != "" && int64(len(x.Password)) < 8,
+	}; failed != [2]bool{} && c.Fail("Signup", "Password", "password", validatorSignupPassword, x.Password, failed[:]...) {
+		return c.Err()
+	}
+	if failed := [...]bool{
+		!(string(x.Confirm) == string(x.Password)),
+	}; failed != [1]bool{} && c.Fail("Signup", "Confirm", "confirm", validatorSignupConfirm, x.Confirm, failed[:]...) {
+		return c.Err()
+	}
+	if failed := [...]bool{
+		x.End != nil && !((*x.End).Compare(x.Start) > 0),
+	}; failed != [1]bool{} && c.Fail("Signup", "End", "end", validatorSignupEnd, x.End, failed[:]...) {
+		return c.Err()
+	}
+	if failed := [...]bool{
+		!(float64(x.Limit) >= float64(x.Min)),
+	}; failed != [1]bool{} && c.Fail("Signup", "Limit", "limit", validatorSignupLimit, x.Limit, failed[:]...) {
+		return c.Err()
+	}
+	if failed := [...]bool{
+		!x.Accepted,
+	}; failed != [1]bool{} && c.Fail("Signup", "Accepted", "accepted", validatorSignupAccepted, x.Accepted, failed[:]...) {
+		return c.Err()
+	}
+	if c.Field("Signup", "Labels", "labels", validatorSignupLabels, x.Labels, nil) {
+		return c.Err()
+	}
+	return c.Err()
+}
@@ -0,0 +1,589 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/types"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/oSethoum/validator"
+	"github.com/oSethoum/validator/cmd/internal/loader"
+)
+
+const generatedSuffix = "_validator.go"
+
+var comparisons = map[string]string{
+	"eqField":  "==",
+	"neField":  "!=",
+	"gtField":  ">",
+	"gteField": ">=",
+	"ltField":  "<",
+	"lteField": "<=",
+}
+
+func validate(args []string) error {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	fs.Parse(args)
+
+	pkgs, err := loader.Load(fs.Args()...)
+	if err != nil {
+		return err
+	}
+	for _, pkg := range pkgs {
+		files := map[string][]*types.TypeName{}
+		for _, s := range pkg.Structs {
+			file := pkg.Fset.Position(s.Pos()).Filename
+			files[file] = append(files[file], s)
+		}
+		for file, structs := range files {
+			src, err := generateFile(pkg, structs)
+			if err != nil {
+				return err
+			}
+			output := strings.TrimSuffix(file, ".go") + generatedSuffix
+			if err := os.WriteFile(output, src, 0o644); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type generator struct {
+	pkg     *loader.Package
+	imports map[string]bool
+	vars    *bytes.Buffer
+	inits   *bytes.Buffer
+	funcs   *bytes.Buffer
+}
+
+func generateFile(pkg *loader.Package, structs []*types.TypeName) ([]byte, error) {
+	g := &generator{
+		pkg:     pkg,
+		imports: map[string]bool{},
+		vars:    &bytes.Buffer{},
+		inits:   &bytes.Buffer{},
+		funcs:   &bytes.Buffer{},
+	}
+	for _, s := range structs {
+		if err := g.generate(s); err != nil {
+			return nil, err
+		}
+	}
+	std := []string{}
+	for path := range g.imports {
+		if !strings.Contains(path, ".") {
+			std = append(std, strconv.Quote(path)+"\n")
+		}
+	}
+	sort.Strings(std)
+	imports := strings.Join(std, "")
+	if imports != "" {
+		imports += "\n"
+	}
+	src := &bytes.Buffer{}
+	fmt.Fprintf(src, "// Code generated by validatorgen. DO NOT EDIT.\n\npackage %s\n\n", pkg.Name)
+	fmt.Fprintf(src, "import (\n%s\"github.com/oSethoum/validator\"\n)\n\nvar (\n%s)\n\nfunc init() {\n%s}\n%s", imports, g.vars, g.inits, g.funcs)
+	return format.Source(src.Bytes())
+}
+
+func (g *generator) generate(obj *types.TypeName) error {
+	named := obj.Type().(*types.Named)
+	for i := 0; i < named.NumMethods(); i++ {
+		m := named.Method(i)
+		if (m.Name() == "Validate" || m.Name() == "ValidateWith") && !strings.HasSuffix(g.pkg.Fset.Position(m.Pos()).Filename, generatedSuffix) {
+			return fmt.Errorf("%s: %s already has a %s method", g.pkg.Fset.Position(m.Pos()), obj.Name(), m.Name())
+		}
+	}
+
+	fmt.Fprintf(g.inits, "\tvalidator.RegisterGenerated((*%s).ValidateWith)\n", obj.Name())
+	fmt.Fprintf(g.funcs, "\nfunc (x *%s) Validate() error {\n\treturn x.ValidateWith(validator.Default())\n}\n", obj.Name())
+	fmt.Fprintf(g.funcs, "\nfunc (x *%s) ValidateWith(v *validator.Validator) error {\n\tc := v.NewCheck()\n", obj.Name())
+	if err := g.fields(obj.Name(), obj.Name(), "x", named, named.Underlying().(*types.Struct)); err != nil {
+		return err
+	}
+	g.funcs.WriteString("\treturn c.Err()\n}\n")
+	return nil
+}
+
+func (g *generator) fields(structName, prefix, expr string, typ types.Type, s *types.Struct) error {
+	for i := 0; i < s.NumFields(); i++ {
+		f := s.Field(i)
+		if f.Embedded() {
+			if es, ok := f.Type().(*types.Named); ok {
+				if us, ok := es.Underlying().(*types.Struct); ok {
+					if err := g.fields(es.Obj().Name(), prefix+f.Name(), expr+"."+f.Name(), es, us); err != nil {
+						return err
+					}
+					continue
+				}
+			}
+		}
+		tag := reflect.StructTag(s.Tag(i))
+		rules, ok := tag.Lookup("validate")
+		if !ok {
+			continue
+		}
+		if !f.Exported() && f.Pkg() != g.pkg.Types {
+			return fmt.Errorf("%s: field %s of %s is not accessible", g.pkg.Fset.Position(f.Pos()), f.Name(), structName)
+		}
+
+		variable := "validator" + prefix + strings.ToUpper(f.Name()[:1]) + f.Name()[1:]
+		field := fieldInfo{structName: structName, name: f.Name(), json: jsonName(f.Name(), tag), expr: expr + "." + f.Name(), variable: variable}
+		constraints := validator.ParseTag(rules)
+		if kind, ok := classify(f.Type()); ok {
+			if err := g.check(field, kind, typ, constraints); err != nil {
+				return fmt.Errorf("%s: field %s of %s: %v", g.pkg.Fset.Position(f.Pos()), f.Name(), structName, err)
+			}
+			continue
+		}
+		g.reflective(field, expr, rules, constraints)
+	}
+	return nil
+}
+
+type fieldInfo struct {
+	structName string
+	name       string
+	json       string
+	expr       string
+	variable   string
+}
+
+// fieldKind is the category of a field type checked directly by the generated
+// code, kind is one of string, int, uint, float, bool and time.
+type fieldKind struct {
+	kind  string
+	ptr   bool
+	slice bool
+}
+
+func classify(t types.Type) (fieldKind, bool) {
+	k := fieldKind{}
+	if p, ok := t.(*types.Pointer); ok {
+		k.ptr = true
+		t = p.Elem()
+	} else if s, ok := t.Underlying().(*types.Slice); ok {
+		k.slice = true
+		t = s.Elem()
+	}
+	if named, ok := t.(*types.Named); ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "time" && named.Obj().Name() == "Time" {
+		k.kind = "time"
+		return k, !k.slice
+	}
+	b, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return k, false
+	}
+	info := b.Info()
+	switch {
+	case info&types.IsString != 0:
+		k.kind = "string"
+	case info&types.IsInteger != 0 && info&types.IsUnsigned != 0:
+		k.kind = "uint"
+	case info&types.IsInteger != 0:
+		k.kind = "int"
+	case info&types.IsFloat != 0:
+		k.kind = "float"
+	case info&types.IsBoolean != 0 && !k.slice:
+		k.kind = "bool"
+	default:
+		return k, false
+	}
+	return k, true
+}
+
+// convert returns expr converted to the type the rules compare the values of a
+// kind with.
+func convert(kind, expr string) string {
+	switch kind {
+	case "string":
+		return "string(" + expr + ")"
+	case "int":
+		return "int64(" + expr + ")"
+	case "uint":
+		return "uint64(" + expr + ")"
+	case "float":
+		return "float64(" + expr + ")"
+	}
+	return expr
+}
+
+// check writes the direct checks of a field, in the order of the violations of
+// the reflection based validation: required, the rules of the kind of the field
+// and the comparisons with the other fields.
+func (g *generator) check(field fieldInfo, kind fieldKind, typ types.Type, constraints []validator.Constraint) error {
+	e := field.expr
+	value := e
+	if kind.ptr {
+		value = "*" + e
+	}
+
+	var missing, present string
+	switch {
+	case kind.ptr || kind.slice:
+		missing, present = e+" == nil", e+" != nil"
+	case kind.kind == "string":
+		missing, present = e+` == ""`, e+` != ""`
+	case kind.kind == "bool":
+		missing, present = "!"+e, e
+	case kind.kind == "time":
+		missing, present = e+" == (time.Time{})", e+" != (time.Time{})"
+	default:
+		missing, present = e+" == 0", e+" != 0"
+	}
+	isRequired := false
+	for _, constraint := range constraints {
+		isRequired = isRequired || constraint.Kind == "required"
+	}
+	guard := ""
+	if kind.ptr || kind.slice || isRequired {
+		guard = present
+	}
+	if kind.kind == "time" && !kind.ptr && isRequired {
+		g.imports["time"] = true
+	}
+
+	checked := []validator.Constraint{}
+	conds := []string{}
+	used := make([]bool, len(constraints))
+	add := func(i int, constraint validator.Constraint, cond string) {
+		used[i] = true
+		checked = append(checked, constraint)
+		conds = append(conds, cond)
+	}
+	for i, constraint := range constraints {
+		if constraint.Kind == "required" {
+			add(i, constraint, missing)
+			break
+		}
+	}
+
+	for i, constraint := range constraints {
+		param, _ := constraint.Param.(string)
+		cond := ""
+		var err error
+		switch {
+		case kind.slice:
+			cond, err = g.listCheck(kind.kind, value, &constraint)
+		case kind.kind == "string":
+			cond, err = g.stringCheck(field, i, value, &constraint)
+		case kind.kind == "int" || kind.kind == "uint" || kind.kind == "float":
+			if constraint.Kind != "min" && constraint.Kind != "max" {
+				continue
+			}
+			var n string
+			if n, constraint.Param, err = number(kind.kind, param); err != nil {
+				return fmt.Errorf("invalid param in %s", constraint.Tag)
+			}
+			op := "<"
+			if constraint.Kind == "max" {
+				op = ">"
+			}
+			cond = fmt.Sprintf("%s %s %s", convert(kind.kind, value), op, n)
+		}
+		if err != nil {
+			return err
+		}
+		if cond == "" {
+			continue
+		}
+		if guard != "" && strings.Contains(cond, "||") {
+			cond = fmt.Sprintf("%s && (%s)", guard, cond)
+		} else if guard != "" {
+			cond = guard + " && " + cond
+		}
+		add(i, constraint, cond)
+	}
+
+	for i, constraint := range constraints {
+		op, ok := comparisons[constraint.Kind]
+		if !ok {
+			continue
+		}
+		cond, err := g.comparison(kind, e, value, typ, constraint, op)
+		if err != nil {
+			return err
+		}
+		add(i, constraint, cond)
+	}
+	if len(conds) == 0 {
+		return nil
+	}
+
+	for i, constraint := range constraints {
+		if !used[i] {
+			checked = append(checked, constraint)
+		}
+	}
+	fmt.Fprintf(g.vars, "\t%s = []validator.Constraint{\n", field.variable)
+	for _, constraint := range checked {
+		fmt.Fprintf(g.vars, "\t\t{Tag: %q, Kind: %q", constraint.Tag, constraint.Kind)
+		if constraint.Param != nil {
+			fmt.Fprintf(g.vars, ", Param: %s", literal(constraint.Param))
+		}
+		g.vars.WriteString("},\n")
+	}
+	g.vars.WriteString("\t}\n")
+
+	fmt.Fprintf(g.funcs, "\tif failed := [...]bool{\n")
+	for _, cond := range conds {
+		fmt.Fprintf(g.funcs, "\t\t%s,\n", cond)
+	}
+	fmt.Fprintf(g.funcs, "\t}; failed != [%d]bool{} && c.Fail(%q, %q, %q, %s, %s, failed[:]...) {\n\t\treturn c.Err()\n\t}\n",
+		len(conds), field.structName, field.name, field.json, field.variable, e)
+	return nil
+}
+
+func (g *generator) stringCheck(field fieldInfo, i int, value string, constraint *validator.Constraint) (string, error) {
+	param, _ := constraint.Param.(string)
+	if validator.RuleRegexp(constraint.Kind) != nil {
+		variable := fmt.Sprintf("%sRegexp%d", field.variable, i)
+		fmt.Fprintf(g.vars, "\t%s = validator.RuleRegexp(%q)\n", variable, constraint.Kind)
+		return fmt.Sprintf("!%s.MatchString(%s)", variable, convert("string", value)), nil
+	}
+	switch constraint.Kind {
+	case "minLen", "maxLen", "len":
+		n, err := strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid param in %s", constraint.Tag)
+		}
+		constraint.Param = n
+		op := map[string]string{"minLen": "<", "maxLen": ">", "len": "!="}[constraint.Kind]
+		return fmt.Sprintf("int64(len(%s)) %s %d", value, op, n), nil
+	case "in", "oneOf", "out":
+		list := strings.Split(strings.ReplaceAll(param, " ", ""), ",")
+		constraint.Param = list
+		if constraint.Kind == "out" {
+			return equalsAny(convert("string", value), "string", list), nil
+		}
+		return "!(" + equalsAny(convert("string", value), "string", list) + ")", nil
+	case "match":
+		if _, err := regexp.Compile(param); err != nil {
+			return "", fmt.Errorf("invalid param in %s", constraint.Tag)
+		}
+		g.imports["regexp"] = true
+		variable := fmt.Sprintf("%sRegexp%d", field.variable, i)
+		fmt.Fprintf(g.vars, "\t%s = regexp.MustCompile(%q)\n", variable, param)
+		return fmt.Sprintf("!%s.MatchString(%s)", variable, convert("string", value)), nil
+	}
+	return "", nil
+}
+
+// listCheck returns the check of the in, out, include and exclude rules of a
+// slice of strings or numbers.
+func (g *generator) listCheck(kind, value string, constraint *validator.Constraint) (string, error) {
+	switch constraint.Kind {
+	case "in", "out", "include", "exclude":
+	default:
+		return "", nil
+	}
+	param, _ := constraint.Param.(string)
+	items := strings.Split(param, ",")
+	list := make([]string, len(items))
+	var typed any
+	switch kind {
+	case "string":
+		typed = items
+		copy(list, items)
+	case "int", "uint", "float":
+		values := make([]any, len(items))
+		for i, item := range items {
+			var err error
+			list[i], values[i], err = number(kind, item)
+			if err != nil {
+				return "", fmt.Errorf("invalid param in %s", constraint.Tag)
+			}
+		}
+		typed = typedList(kind, values)
+	}
+	constraint.Param = typed
+
+	v := convert(kind, "v")
+	switch constraint.Kind {
+	case "in":
+		return fmt.Sprintf("func() bool {\nfor _, v := range %s {\nif !(%s) {\nreturn true\n}\n}\nreturn false\n}()", value, equalsAny(v, kind, list)), nil
+	case "out", "exclude":
+		return fmt.Sprintf("func() bool {\nfor _, v := range %s {\nif %s {\nreturn true\n}\n}\nreturn false\n}()", value, equalsAny(v, kind, list)), nil
+	}
+	missing := []string{}
+	for _, item := range list {
+		missing = append(missing, fmt.Sprintf("!func() bool {\nfor _, v := range %s {\nif %s == %s {\nreturn true\n}\n}\nreturn false\n}()", value, v, goConst(kind, item)))
+	}
+	return strings.Join(missing, " ||\n"), nil
+}
+
+// comparison returns the check of a comparison with another field of the
+// struct, the nil pointers are not compared.
+func (g *generator) comparison(kind fieldKind, e, value string, typ types.Type, constraint validator.Constraint, op string) (string, error) {
+	name, _ := constraint.Param.(string)
+	obj, _, _ := types.LookupFieldOrMethod(typ, true, g.pkg.Types, name)
+	other, ok := obj.(*types.Var)
+	if !ok || !other.IsField() {
+		return "", fmt.Errorf("invalid param in %s", constraint.Tag)
+	}
+	otherKind, ok := classify(other.Type())
+	if !ok || kind.slice || otherKind.slice {
+		return "", fmt.Errorf("cannot compare in %s", constraint.Tag)
+	}
+	o := e[:strings.LastIndex(e, ".")+1] + name
+	guards := []string{}
+	if kind.ptr {
+		guards = append(guards, e+" != nil")
+	}
+	otherValue := o
+	if otherKind.ptr {
+		guards = append(guards, o+" != nil")
+		otherValue = "*" + o
+	}
+
+	a, b := kind.kind, otherKind.kind
+	isNumber := func(k string) bool { return k == "int" || k == "uint" || k == "float" }
+	var cmp string
+	switch {
+	case a == "time" && b == "time":
+		if kind.ptr {
+			value = "(" + value + ")"
+		}
+		cmp = fmt.Sprintf("%s.Compare(%s) %s 0", value, otherValue, op)
+	case a == "string" && b == "string", a == b && isNumber(a):
+		cmp = fmt.Sprintf("%s %s %s", convert(a, value), op, convert(a, otherValue))
+	case isNumber(a) && isNumber(b):
+		cmp = fmt.Sprintf("float64(%s) %s float64(%s)", value, op, otherValue)
+	case a == "bool" && b == "bool":
+		cmp = map[string]string{
+			"==": "%[1]s == %[2]s",
+			"!=": "%[1]s != %[2]s",
+			">":  "%[1]s && !%[2]s",
+			">=": "%[1]s || !%[2]s",
+			"<":  "!%[1]s && %[2]s",
+			"<=": "!%[1]s || %[2]s",
+		}[op]
+		cmp = fmt.Sprintf(cmp, value, otherValue)
+	default:
+		return "", fmt.Errorf("cannot compare in %s", constraint.Tag)
+	}
+	return strings.Join(append(guards, "!("+cmp+")"), " && "), nil
+}
+
+// reflective writes the check of a field through Check.Field, for the types
+// which are not checked directly.
+func (g *generator) reflective(field fieldInfo, expr, rules string, constraints []validator.Constraint) {
+	fmt.Fprintf(g.vars, "\t%s = validator.ParseTag(%s)\n", field.variable, strconv.Quote(rules))
+
+	fields := "nil"
+	others := []string{}
+	for _, constraint := range constraints {
+		if param, ok := constraint.Param.(string); ok && comparisons[constraint.Kind] != "" {
+			others = append(others, param)
+		}
+	}
+	if len(others) > 0 {
+		sort.Strings(others)
+		cases := &bytes.Buffer{}
+		for _, other := range others {
+			fmt.Fprintf(cases, "case %q:\nreturn %s.%s\n", other, expr, other)
+		}
+		fields = fmt.Sprintf("func(name string) any {\nswitch name {\n%s}\nreturn nil\n}", cases)
+	}
+	fmt.Fprintf(g.funcs, "\tif c.Field(%q, %q, %q, %s, %s, %s) {\n\t\treturn c.Err()\n\t}\n",
+		field.structName, field.name, field.json, field.variable, field.expr, fields)
+}
+
+// number parses the param of a rule of a number kind, it returns the Go constant
+// and the typed param.
+func number(kind, param string) (string, any, error) {
+	switch kind {
+	case "int":
+		n, err := strconv.ParseInt(param, 10, 64)
+		return strconv.FormatInt(n, 10), n, err
+	case "uint":
+		n, err := strconv.ParseUint(param, 10, 64)
+		return strconv.FormatUint(n, 10), n, err
+	}
+	n, err := strconv.ParseFloat(param, 64)
+	if err == nil && (n != n || n > 1e308 || n < -1e308) {
+		err = fmt.Errorf("invalid param %s", param)
+	}
+	return strconv.FormatFloat(n, 'g', -1, 64), n, err
+}
+
+func typedList(kind string, values []any) any {
+	switch kind {
+	case "int":
+		list := []int64{}
+		for _, value := range values {
+			list = append(list, value.(int64))
+		}
+		return list
+	case "uint":
+		list := []uint64{}
+		for _, value := range values {
+			list = append(list, value.(uint64))
+		}
+		return list
+	}
+	list := []float64{}
+	for _, value := range values {
+		list = append(list, value.(float64))
+	}
+	return list
+}
+
+func goConst(kind, value string) string {
+	if kind == "string" {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+func equalsAny(expr, kind string, list []string) string {
+	conds := []string{}
+	for _, item := range list {
+		conds = append(conds, expr+" == "+goConst(kind, item))
+	}
+	return strings.Join(conds, " || ")
+}
+
+// literal returns the Go expression of a typed param.
+func literal(param any) string {
+	switch p := param.(type) {
+	case string:
+		return strconv.Quote(p)
+	case int64:
+		return fmt.Sprintf("int64(%d)", p)
+	case uint64:
+		return fmt.Sprintf("uint64(%d)", p)
+	case float64:
+		return fmt.Sprintf("float64(%s)", strconv.FormatFloat(p, 'g', -1, 64))
+	case []uint64:
+		items := []string{}
+		for _, item := range p {
+			items = append(items, strconv.FormatUint(item, 10))
+		}
+		return "[]uint64{" + strings.Join(items, ", ") + "}"
+	case []float64:
+		items := []string{}
+		for _, item := range p {
+			items = append(items, strconv.FormatFloat(item, 'g', -1, 64))
+		}
+		return "[]float64{" + strings.Join(items, ", ") + "}"
+	}
+	return fmt.Sprintf("%#v", param)
+}
+
+func jsonName(name string, tag reflect.StructTag) string {
+	if json, ok := tag.Lookup("json"); ok {
+		if n := strings.Split(json, ",")[0]; n != "" {
+			return n
+		}
+	}
+	return name
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/oSethoum/validator"
+	"github.com/oSethoum/validator/cmd/internal/loader"
+	"github.com/oSethoum/validator/cmd/validatorgen/testdata/signup"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestGenerateFile(t *testing.T) {
+	pkgs, err := loader.Load("./testdata/signup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := generateFile(pkgs[0], pkgs[0].Structs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden := filepath.Join("testdata", "signup", "signup"+generatedSuffix)
+	if *update {
+		if err := os.WriteFile(golden, src, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(src, expected) {
+		t.Errorf("generated code differs from %s, run go test -update to see the diff:\n%s", golden, src)
+	}
+}
+
+// TestGenerated runs the golden output against the reflection based validation.
+func TestGenerated(t *testing.T) {
+	score, high, code, end := 0.25, 0.75, "abc-12", time.Now()
+	valid := signup.Signup{
+		Base:     signup.Base{ID: "u-01", Created: end},
+		Name:     "Oussama",
+		Email:    "oussama@example.com",
+		Age:      30,
+		Score:    &score,
+		Role:     "user",
+		Code:     &code,
+		Roles:    []signup.Role{"admin", "user"},
+		Codes:    []int{1, 2},
+		Password: "password",
+		Confirm:  "password",
+		Start:    end.Add(-time.Hour),
+		End:      &end,
+		Min:      1,
+		Limit:    2,
+		Accepted: true,
+		Labels:   map[string]string{"plan": "free"},
+	}
+	invalid := valid
+	invalid.Base = signup.Base{ID: "u-1"}
+	invalid.Name, invalid.Email, invalid.Age = "jo3", "nope", 10
+	invalid.Score, invalid.Credits, invalid.Role = &high, 250, "root"
+	invalid.Code = new(string)
+	invalid.Roles, invalid.Codes = []signup.Role{"admin", "root"}, []int{0, 13}
+	invalid.Password, invalid.Confirm = "pass", "word"
+	invalid.Start, invalid.Min = end, 3
+	invalid.Accepted, invalid.Labels = false, nil
+	for _, s := range []signup.Signup{valid, invalid} {
+		for _, options := range [][]validator.Option{nil, {validator.MaxErrors(2)}, {validator.StopOnFirstError()}} {
+			reflective := validator.New(options...)
+			reflective.RegisterStructRules(signup.Signup{}, map[string]string{})
+			expected := reflective.Struct(s)
+			if generated := validator.New(options...).Struct(s); !reflect.DeepEqual(generated, expected) {
+				t.Errorf("expected %+v, got %+v", expected, generated)
+			}
+		}
+	}
+	if err := validator.Struct(valid); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	v := validator.New()
+	v.RegisterStructRules(signup.Signup{}, map[string]string{"Age": "min=31"})
+	if err := v.Struct(&valid); err == nil {
+		t.Error("expected the registered rules to bypass the generated validator")
+	}
+}
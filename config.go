@@ -0,0 +1,170 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+type Position struct {
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Column int    `json:"column,omitempty"`
+}
+
+func (p Position) String() string {
+	if p.Line == 0 {
+		return p.File
+	}
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// A ConfigDecoder decodes data into dst and returns the positions of the keys by
+// path, such as "db.url" or "hosts[0]". The File of the positions is set by
+// LoadConfig.
+type ConfigDecoder func(data []byte, dst any) (map[string]Position, error)
+
+var (
+	configDecodersMu sync.RWMutex
+	configDecoders   = map[string]ConfigDecoder{".json": DecodeJSONConfig}
+)
+
+// RegisterConfigDecoder sets the decoder of the config files with the extension
+// ext, such as ".yaml" or ".toml".
+func RegisterConfigDecoder(ext string, decoder ConfigDecoder) {
+	configDecodersMu.Lock()
+	defer configDecodersMu.Unlock()
+	configDecoders[strings.ToLower(ext)] = decoder
+}
+
+// LoadConfig decodes the config file at path into dst with the decoder of its
+// extension and validates it, the fields errors have the position of their key
+// in the file.
+func LoadConfig(path string, dst any) error {
+	return defaultValidator.LoadConfig(path, dst)
+}
+
+func (v *Validator) LoadConfig(path string, dst any) error {
+	configDecodersMu.RLock()
+	decoder, ok := configDecoders[strings.ToLower(filepath.Ext(path))]
+	configDecodersMu.RUnlock()
+	if !ok {
+		return fmt.Errorf("validate: no config decoder for %s", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	positions, err := decoder(data, dst)
+	if err != nil {
+		var positionError *PositionError
+		if errors.As(err, &positionError) {
+			positionError.File = path
+			return err
+		}
+		return fmt.Errorf("validate: decode %s: %w", path, err)
+	}
+
+	err = v.Struct(dst)
+	var e *Error
+	if !errors.As(err, &e) {
+		return err
+	}
+	for i := range e.FieldsErrors {
+		position := positions[e.FieldsErrors[i].Field]
+		position.File = path
+		e.FieldsErrors[i].Position = &position
+	}
+	return e
+}
+
+// PositionError is an error located in a config file.
+type PositionError struct {
+	Position
+	Err error
+}
+
+func (e *PositionError) Error() string {
+	return e.Position.String() + ": " + e.Err.Error()
+}
+
+func (e *PositionError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeJSONConfig is the ConfigDecoder of the JSON files.
+func DecodeJSONConfig(data []byte, dst any) (map[string]Position, error) {
+	positions := map[string]Position{}
+	err := jsonPositions(json.NewDecoder(bytes.NewReader(data)), data, "", positions)
+	if err == nil {
+		err = json.Unmarshal(data, dst)
+	}
+	var syntaxError *json.SyntaxError
+	var typeError *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxError):
+		return nil, &PositionError{Position: offsetPosition(data, syntaxError.Offset), Err: err}
+	case errors.As(err, &typeError):
+		position, ok := positions[typeError.Field]
+		if !ok {
+			position = offsetPosition(data, typeError.Offset)
+		}
+		return nil, &PositionError{Position: position, Err: err}
+	}
+	return positions, err
+}
+
+// jsonPositions records the positions of the keys and items of the next value.
+func jsonPositions(decoder *json.Decoder, data []byte, path string, positions map[string]Position) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	switch token {
+	case json.Delim('{'):
+		for decoder.More() {
+			offset := decoder.InputOffset()
+			token, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			key := token.(string)
+			if path != "" {
+				key = path + "." + key
+			}
+			positions[key] = offsetPosition(data, offset)
+			if err := jsonPositions(decoder, data, key, positions); err != nil {
+				return err
+			}
+		}
+		_, err = decoder.Token()
+	case json.Delim('['):
+		for i := 0; decoder.More(); i++ {
+			key := fmt.Sprintf("%s[%d]", path, i)
+			positions[key] = offsetPosition(data, decoder.InputOffset())
+			if err := jsonPositions(decoder, data, key, positions); err != nil {
+				return err
+			}
+		}
+		_, err = decoder.Token()
+	}
+	return err
+}
+
+// offsetPosition returns the position of the first token at or after offset.
+func offsetPosition(data []byte, offset int64) Position {
+	for offset < int64(len(data)) && strings.IndexByte(" \t\r\n,:", data[offset]) >= 0 {
+		offset++
+	}
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	return Position{Line: line, Column: len(before) - bytes.LastIndexByte(before, '\n')}
+}
@@ -27,19 +27,15 @@ const (
 	out          = "out"
 	include      = "include"
 	exclude      = "exclude"
-)
-
-const (
-	typeString      = "string"
-	typeStringPtr   = "*string"
-	typeStringArray = "[]string"
-	typeInt         = "int"
-	typeIntPtr      = "*int"
-	typeIntArray    = "[]int"
-	typeUint        = "uint"
-	typeUintPtr     = "*uint"
-	typeUintArray   = "[]uint"
-	typeFloat       = "float"
-	typeFloatPtr    = "*float"
-	typeFloatArray  = "[]float"
+	sensitive    = "sensitive"
+	redact       = "redact"
+	bail         = "bail"
+	eqField      = "eqField"
+	neField      = "neField"
+	gtField      = "gtField"
+	gteField     = "gteField"
+	ltField      = "ltField"
+	lteField     = "lteField"
+	typeRule     = "type"
+	defaultRule  = "default"
 )
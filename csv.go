@@ -0,0 +1,115 @@
+package validator
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+type CSVSummary struct {
+	Rows    int `json:"rows"`
+	Valid   int `json:"valid"`
+	Invalid int `json:"invalid"`
+	// Rules counts the violations by rule kind.
+	Rules map[string]int `json:"rules"`
+}
+
+// RejectedRows writes the invalid rows read by ReadCSV to w as CSV, with the
+// header and an errors column appended.
+func RejectedRows(w io.Writer) BatchOption {
+	return func(b *batch) {
+		b.rejected = w
+	}
+}
+
+// ReadCSV binds the rows of r to a struct type T by the header names of their
+// csv tag, validates them and calls fn, which may be nil, with the line number
+// of the row. The fields errors are named by the columns and hold the raw cells,
+// the cells which cannot be converted are type violations.
+func ReadCSV[T any](r io.Reader, fn func(row int, item T, err error) error, options ...BatchOption) (CSVSummary, error) {
+	b := newBatch(options)
+	summary := CSVSummary{Rules: map[string]int{}}
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err == io.EOF {
+		return summary, nil
+	}
+	if err != nil {
+		return summary, err
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	var rejected *csv.Writer
+	if b.rejected != nil {
+		rejected = csv.NewWriter(b.rejected)
+		defer rejected.Flush()
+		if err := rejected.Write(append(header[:len(header):len(header)], "errors")); err != nil {
+			return summary, err
+		}
+	}
+
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	scopes := b.v.scopes(nil, t, "")
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return summary, err
+		}
+		row, _ := reader.FieldPos(0)
+		summary.Rows++
+
+		var item T
+		e := &Error{}
+		err = b.v.bindStruct(t, reflect.ValueOf(&item).Elem(), func(ft reflect.StructField) (string, []string, bool) {
+			key := bindKey(ft, []string{"csv"})
+			if i, ok := columns[key]; ok && i < len(record) {
+				return key, record[i : i+1], true
+			}
+			return key, nil, false
+		}, true, e, scopes)
+		if err != nil {
+			return summary, err
+		}
+
+		var rowError error
+		if len(e.FieldsErrors) == 0 {
+			summary.Valid++
+		} else {
+			rowError = e
+			summary.Invalid++
+			messages := []string{}
+			for _, fieldError := range e.FieldsErrors {
+				for _, violation := range fieldError.Violations {
+					summary.Rules[violation.Kind]++
+					messages = append(messages, fmt.Sprintf("%s: %s", fieldError.Field, violation.Message()))
+				}
+			}
+			if rejected != nil {
+				if err := rejected.Write(append(record[:len(record):len(record)], strings.Join(messages, "; "))); err != nil {
+					return summary, err
+				}
+			}
+		}
+		if fn != nil {
+			if err := fn(row, item, rowError); err != nil {
+				return summary, err
+			}
+		}
+		if b.stopAfter > 0 && summary.Invalid >= b.stopAfter {
+			return summary, ErrTooManyInvalid
+		}
+	}
+	if rejected != nil {
+		rejected.Flush()
+		return summary, rejected.Error()
+	}
+	return summary, nil
+}
@@ -0,0 +1,104 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// WithDefaults makes Struct apply the defaults of the fields before validating
+// when it is given a pointer.
+func WithDefaults() Option {
+	return func(v *Validator) {
+		v.defaults = true
+	}
+}
+
+// ApplyDefaults sets the zero-valued fields of the struct s points to from their
+// default tag, or from their default rule, and descends into the nested structs.
+// The slices defaults are split on commas.
+//
+//	type Server struct {
+//		Host    string        `default:"localhost"`
+//		Timeout time.Duration `validate:"default=5s;min=1"`
+//		Origins []string      `default:"a.com,b.com"`
+//	}
+func ApplyDefaults(s any) {
+	defaultValidator.ApplyDefaults(s)
+}
+
+func (v *Validator) ApplyDefaults(s any) {
+	rv := reflect.ValueOf(s)
+	if rv.Kind() != reflect.Pointer || rv.Type().Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validate: ApplyDefaults expects a pointer to a struct, got %T", s))
+	}
+	if rv.IsNil() {
+		return
+	}
+	t := rv.Type().Elem()
+	v.applyDefaults(t, rv.Elem(), v.scopes(nil, t, ""))
+}
+
+func (v *Validator) applyDefaults(t reflect.Type, rv reflect.Value, scopes []rulesScope) {
+	for i := 0; i < t.NumField(); i++ {
+		ft := t.Field(i)
+		fv := rv.Field(i)
+		if !ft.IsExported() {
+			continue
+		}
+
+		if ft.Anonymous && ft.Type.Kind() == reflect.Struct {
+			v.applyDefaults(ft.Type, fv, v.scopes(scopes, ft.Type, ft.Name))
+			continue
+		}
+
+		if value, ok := v.fieldDefault(scopes, ft); ok && (isMissing(fv) || fv.Kind() == reflect.Slice && fv.Len() == 0) {
+			dv, ok := defaultValue(ft.Type, value)
+			if !ok {
+				panic(fmt.Sprintf("validate: struct %s field %s invalid default %q", t.Name(), ft.Name, value))
+			}
+			fv.Set(dv)
+			continue
+		}
+
+		st := ft.Type
+		if st.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				continue
+			}
+			st, fv = st.Elem(), fv.Elem()
+		}
+		if st.Kind() == reflect.Struct && st != timeType {
+			v.applyDefaults(st, fv, v.scopes(nil, st, ""))
+		}
+	}
+}
+
+// fieldDefault returns the default tag of a field, or the param of its default rule.
+func (v *Validator) fieldDefault(scopes []rulesScope, ft reflect.StructField) (string, bool) {
+	if value, ok := ft.Tag.Lookup("default"); ok {
+		return value, true
+	}
+	constraints, _ := v.fieldConstraints(scopes, ft)
+	for _, constraint := range constraints {
+		if constraint.Kind == defaultRule {
+			param, _ := getStringParam(constraint.Param)
+			return param, true
+		}
+	}
+	return "", false
+}
+
+// defaultValue converts the default of a field of type t.
+func defaultValue(t reflect.Type, value string) (reflect.Value, bool) {
+	input := []string{value}
+	if st := indirect(t); st.Kind() == reflect.Slice && st.Elem().Kind() != reflect.Uint8 {
+		input = strings.Split(value, ",")
+		for i := range input {
+			input[i] = strings.TrimSpace(input[i])
+		}
+	}
+	dv := reflect.New(t).Elem()
+	_, ok := setValue(dv, input)
+	return dv, ok
+}
@@ -0,0 +1,105 @@
+package validator
+
+import (
+	"fmt"
+	"html"
+	"reflect"
+	"strings"
+)
+
+type Description struct {
+	Type   string             `json:"type"`
+	Fields []FieldDescription `json:"fields"`
+}
+
+type FieldDescription struct {
+	// Path is the name of the field in the errors.
+	Path    string       `json:"path"`
+	Type    string       `json:"type"`
+	Rules   []Constraint `json:"rules"`
+	Message string       `json:"message"`
+}
+
+// Describe lists the validated fields of the struct s with their rules, in the
+// order they are checked by Struct.
+func Describe(s any) Description {
+	return defaultValidator.Describe(s)
+}
+
+func (v *Validator) Describe(s any) Description {
+	t := indirect(reflect.TypeOf(s))
+	d := Description{Type: t.Name()}
+	v.describe(&d, t, v.scopes(nil, t, ""))
+	return d
+}
+
+func (v *Validator) describe(d *Description, t reflect.Type, scopes []rulesScope) {
+	for i := 0; i < t.NumField(); i++ {
+		ft := t.Field(i)
+		if ft.Anonymous && ft.Type.Kind() == reflect.Struct {
+			v.describe(d, ft.Type, v.scopes(scopes, ft.Type, ft.Name))
+			continue
+		}
+		constraints, ok := v.fieldConstraints(scopes, ft)
+		if !ok || len(constraints) == 0 {
+			continue
+		}
+		messages := []string{}
+		for _, constraint := range constraints {
+			messages = append(messages, constraint.Message())
+		}
+		d.Fields = append(d.Fields, FieldDescription{
+			Path:    fieldName(ft),
+			Type:    ft.Type.String(),
+			Rules:   append([]Constraint(nil), constraints...),
+			Message: strings.Join(messages, "; "),
+		})
+	}
+}
+
+// Markdown renders the description as a heading followed by a table.
+func (d Description) Markdown() string {
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "### %s\n\n| Field | Type | Rules | Description |\n| --- | --- | --- | --- |\n", d.Type)
+	for _, field := range d.Fields {
+		rules := []string{}
+		for _, rule := range field.Rules {
+			rules = append(rules, markdownCode(rule.Tag))
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", markdownCode(field.Path), markdownCode(field.Type), strings.Join(rules, " "), markdownText(field.Message))
+	}
+	return b.String()
+}
+
+// HTML renders the description as a table captioned with the type name.
+func (d Description) HTML() string {
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "<table>\n<caption>%s</caption>\n<thead>\n<tr><th>Field</th><th>Type</th><th>Rules</th><th>Description</th></tr>\n</thead>\n<tbody>\n", html.EscapeString(d.Type))
+	for _, field := range d.Fields {
+		rules := []string{}
+		for _, rule := range field.Rules {
+			rules = append(rules, "<code>"+html.EscapeString(rule.Tag)+"</code>")
+		}
+		fmt.Fprintf(&b, "<tr><td><code>%s</code></td><td><code>%s</code></td><td>%s</td><td>%s</td></tr>\n",
+			html.EscapeString(field.Path), html.EscapeString(field.Type), strings.Join(rules, " "), html.EscapeString(field.Message))
+	}
+	b.WriteString("</tbody>\n</table>\n")
+	return b.String()
+}
+
+func markdownText(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
+
+// markdownCode wraps s in a code span, the backticks of s are handled with a
+// longer delimiter.
+func markdownCode(s string) string {
+	delimiter := "`"
+	for strings.Contains(s, delimiter) {
+		delimiter += "`"
+	}
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		s = " " + s + " "
+	}
+	return delimiter + markdownText(s) + delimiter
+}
@@ -0,0 +1,64 @@
+package validator
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// LoadEnv sets the fields of the struct pointed to by cfg from the environment
+// variables named by their env tag and validates it. The envDefault tag is used
+// when a variable is unset, the slices are split on commas or on the envSeparator
+// tag. The errors are named by the variables and reported all at once.
+//
+//	type Config struct {
+//		DatabaseURL string   `env:"DB_URL" validate:"required;url"`
+//		Port        int      `env:"PORT" envDefault:"8080" validate:"min=1;max=65535"`
+//		Hosts       []string `env:"HOSTS" envSeparator:";"`
+//	}
+func LoadEnv(cfg any) error {
+	return defaultValidator.LoadEnv(cfg)
+}
+
+func (v *Validator) LoadEnv(cfg any) error {
+	rv := reflect.ValueOf(cfg)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validate: LoadEnv expects a pointer to a struct, got %T", cfg))
+	}
+	rv = rv.Elem()
+	e := &Error{}
+	if err := v.bindStruct(rv.Type(), rv, lookupEnv, false, e, v.scopes(nil, rv.Type(), "")); err != nil {
+		return err
+	}
+	if len(e.FieldsErrors) > 0 {
+		return e
+	}
+	return nil
+}
+
+func lookupEnv(ft reflect.StructField) (string, []string, bool) {
+	key, _, _ := strings.Cut(ft.Tag.Get("env"), ",")
+	if key == "" {
+		return fieldName(ft), nil, false
+	}
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		value, ok = ft.Tag.Lookup("envDefault")
+	}
+	if !ok {
+		return key, nil, false
+	}
+	if t := indirect(ft.Type); t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8 {
+		separator, ok := ft.Tag.Lookup("envSeparator")
+		if !ok {
+			separator = ","
+		}
+		items := strings.Split(value, separator)
+		for i := range items {
+			items[i] = strings.TrimSpace(items[i])
+		}
+		return key, items, true
+	}
+	return key, []string{value}, true
+}
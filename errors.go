@@ -0,0 +1,139 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrRequired     = errors.New("validate: required")
+	ErrAlpha        = errors.New("validate: alpha")
+	ErrURL          = errors.New("validate: url")
+	ErrAlphaSpace   = errors.New("validate: alphaSpace")
+	ErrAlphaNumeric = errors.New("validate: alphaNumeric")
+	ErrNumeric      = errors.New("validate: numeric")
+	ErrNumber       = errors.New("validate: number")
+	ErrHexadecimal  = errors.New("validate: hexadecimal")
+	ErrHexColor     = errors.New("validate: hexColor")
+	ErrRGB          = errors.New("validate: rgb")
+	ErrRGBA         = errors.New("validate: rgba")
+	ErrHSL          = errors.New("validate: hsl")
+	ErrHSLA         = errors.New("validate: hsla")
+	ErrEmail        = errors.New("validate: email")
+	ErrCron         = errors.New("validate: cron")
+	ErrMin          = errors.New("validate: min")
+	ErrMax          = errors.New("validate: max")
+	ErrLen          = errors.New("validate: len")
+	ErrMinLen       = errors.New("validate: minLen")
+	ErrMaxLen       = errors.New("validate: maxLen")
+	ErrMatch        = errors.New("validate: match")
+	ErrOneOf        = errors.New("validate: oneOf")
+	ErrIn           = errors.New("validate: in")
+	ErrOut          = errors.New("validate: out")
+	ErrInclude      = errors.New("validate: include")
+	ErrExclude      = errors.New("validate: exclude")
+	ErrEqField      = errors.New("validate: eqField")
+	ErrNeField      = errors.New("validate: neField")
+	ErrGtField      = errors.New("validate: gtField")
+	ErrGteField     = errors.New("validate: gteField")
+	ErrLtField      = errors.New("validate: ltField")
+	ErrLteField     = errors.New("validate: lteField")
+	ErrType         = errors.New("validate: type")
+)
+
+var kindErrors = map[string]error{
+	required:     ErrRequired,
+	alpha:        ErrAlpha,
+	url:          ErrURL,
+	alphaSpace:   ErrAlphaSpace,
+	alphaNumeric: ErrAlphaNumeric,
+	numeric:      ErrNumeric,
+	number:       ErrNumber,
+	hexadecimal:  ErrHexadecimal,
+	hexColor:     ErrHexColor,
+	rgb:          ErrRGB,
+	rgba:         ErrRGBA,
+	hsl:          ErrHSL,
+	hsla:         ErrHSLA,
+	email:        ErrEmail,
+	cron:         ErrCron,
+	min:          ErrMin,
+	max:          ErrMax,
+	length:       ErrLen,
+	minLen:       ErrMinLen,
+	maxLen:       ErrMaxLen,
+	match:        ErrMatch,
+	oneOf:        ErrOneOf,
+	in:           ErrIn,
+	out:          ErrOut,
+	include:      ErrInclude,
+	exclude:      ErrExclude,
+	eqField:      ErrEqField,
+	neField:      ErrNeField,
+	gtField:      ErrGtField,
+	gteField:     ErrGteField,
+	ltField:      ErrLtField,
+	lteField:     ErrLteField,
+	typeRule:     ErrType,
+}
+
+func (e FieldError) Error() string {
+	vErrs := []string{}
+	for _, v := range e.Violations {
+		if v.Param != nil {
+			vErrs = append(vErrs, fmt.Sprintf("%s=%+v", v.Kind, v.Param))
+		} else {
+			vErrs = append(vErrs, v.Kind)
+		}
+	}
+	if e.Position != nil {
+		return fmt.Sprintf("%s: validate: field %s: %s", e.Position, e.Field, strings.Join(vErrs, " | "))
+	}
+	return fmt.Sprintf("validate: field %s: %s", e.Field, strings.Join(vErrs, " | "))
+}
+
+// Unwrap returns the sentinel errors of the violated rules, so that
+// errors.Is(err, ErrRequired) works on both FieldError and Error.
+func (e FieldError) Unwrap() []error {
+	errs := []error{}
+	for _, v := range e.Violations {
+		if err, ok := kindErrors[v.Kind]; ok {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func (e *Error) Unwrap() []error {
+	errs := make([]error, len(e.FieldsErrors))
+	for i, err := range e.FieldsErrors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Field returns the error of the field at path, or nil when the field is valid.
+func (e *Error) Field(path string) *FieldError {
+	for i := range e.FieldsErrors {
+		if e.FieldsErrors[i].Field == path {
+			return &e.FieldsErrors[i]
+		}
+	}
+	return nil
+}
+
+func (e *Error) HasViolation(path, kind string) bool {
+	if err := e.Field(path); err != nil {
+		return hasConstraint(err.Violations, kind)
+	}
+	return false
+}
+
+func (e *Error) ByPath() map[string][]Constraint {
+	paths := map[string][]Constraint{}
+	for _, err := range e.FieldsErrors {
+		paths[err.Field] = append(paths[err.Field], err.Violations...)
+	}
+	return paths
+}
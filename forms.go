@@ -0,0 +1,123 @@
+package validator
+
+import (
+	"fmt"
+	"html/template"
+	"reflect"
+	"strings"
+)
+
+// Attrs returns the HTML input attributes enforcing the rules of the field of
+// s named by its Go name or path, such as required, maxlength or pattern. The
+// browsers count the lengths in UTF-16 code units, never more than the UTF-8
+// bytes counted by the rules, so minLen has no minlength, which would reject
+// valid values, and maxlength may accept values that maxLen rejects.
+func Attrs(s any, field string) template.HTMLAttr {
+	return defaultValidator.Attrs(s, field)
+}
+
+// FuncMap returns the template functions of the default validator:
+//
+//	<input name="name" {{ validatorAttrs .User "Name" }}>
+func FuncMap() template.FuncMap {
+	return defaultValidator.FuncMap()
+}
+
+func (v *Validator) FuncMap() template.FuncMap {
+	return template.FuncMap{"validatorAttrs": v.Attrs}
+}
+
+func (v *Validator) Attrs(s any, field string) template.HTMLAttr {
+	schema := v.Schema(s)
+	for _, f := range schema.Fields {
+		if f.Path == field || f.Name == field {
+			return template.HTMLAttr(strings.Join(formAttrs(f), " "))
+		}
+	}
+	panic(fmt.Sprintf("validate: struct %s has no field %s", schema.Name, field))
+}
+
+func formAttrs(field FieldSchema) []string {
+	attrs := []string{}
+	attr := func(name string, value any) {
+		attrs = append(attrs, fmt.Sprintf("%s=\"%s\"", name, template.HTMLEscapeString(fmt.Sprint(value))))
+	}
+	switch {
+	case hasConstraint(field.Constraints, email):
+		attr("type", "email")
+	case hasConstraint(field.Constraints, url):
+		attr("type", "url")
+	case isIntKind(field.Kind) || isUintKind(field.Kind):
+		attr("type", "number")
+	case isFloatKind(field.Kind):
+		attr("type", "number")
+		attr("step", "any")
+	}
+	for _, constraint := range field.Constraints {
+		if exp, ok := regexMap[constraint.Kind]; ok && constraint.Kind != email && constraint.Kind != url {
+			if pattern, ok := htmlPattern(exp.String()); ok {
+				attr("pattern", pattern)
+			}
+			continue
+		}
+		switch constraint.Kind {
+		case required:
+			attrs = append(attrs, required)
+		case maxLen, length:
+			attr("maxlength", constraint.Param)
+		case min, max:
+			if field.Kind != reflect.Slice {
+				attr(constraint.Kind, constraint.Param)
+			}
+		case match:
+			if pattern, ok := htmlPattern(fmt.Sprint(constraint.Param)); ok {
+				attr("pattern", pattern)
+			}
+		}
+	}
+	return attrs
+}
+
+// htmlPattern translates a go regular expression to the pattern attribute,
+// which must match the whole value and is compiled with the v flag. The
+// patterns relying on flags are not supported.
+func htmlPattern(pattern string) (string, bool) {
+	source, flags := jsRegexp(pattern)
+	if strings.Trim(flags, "u") != "" {
+		return "", false
+	}
+	b := strings.Builder{}
+	class := false
+	for i := 0; i < len(source); i++ {
+		c := source[i]
+		switch {
+		case c == '\\' && i+1 < len(source):
+			b.WriteString(source[i : i+2])
+			i++
+		case !class && c == '[':
+			class = true
+			b.WriteByte(c)
+			if strings.HasPrefix(source[i+1:], "^") {
+				b.WriteByte('^')
+				i++
+			}
+			// a leading - or ] is a literal
+			if i+1 < len(source) && (source[i+1] == '-' || source[i+1] == ']') {
+				b.WriteString("\\" + string(source[i+1]))
+				i++
+			}
+		case class && c == ']':
+			class = false
+			b.WriteByte(c)
+		case class && c == '-' && i+1 < len(source) && source[i+1] == ']':
+			b.WriteString("\\-")
+		case class && strings.IndexByte("()[{}/|", c) >= 0:
+			b.WriteString("\\" + string(c))
+		default:
+			b.WriteByte(c)
+		}
+	}
+	// the pattern matches anywhere in the value, ^ and $ still anchor at its ends
+	// within the group, whatever the alternations
+	return "[\\s\\S]*(?:" + b.String() + ")[\\s\\S]*", true
+}
@@ -0,0 +1,160 @@
+package validator
+
+import (
+	"reflect"
+	"regexp"
+	"sync"
+)
+
+// Check collects the fields errors of a generated validator.
+type Check struct {
+	v *Validator
+	e *Error
+}
+
+func Default() *Validator {
+	return defaultValidator
+}
+
+// ParseTag parses a validate tag, the regular expressions of its match rules
+// are compiled ahead of their first use.
+func ParseTag(tag string) []Constraint {
+	constraints := parseConstraints(tag)
+	for _, constraint := range constraints {
+		if param, ok := getStringParam(constraint.Param); ok && constraint.Kind == match {
+			compileMatch(param)
+		}
+	}
+	return constraints
+}
+
+func (v *Validator) NewCheck() *Check {
+	return &Check{v: v, e: &Error{}}
+}
+
+// Field checks the value of one field with reflection and reports whether the
+// validation must stop, fields looks up the values of the fields used by the
+// comparison rules. The generated validators use it for the fields they cannot
+// check directly, such as structs and maps.
+func (c *Check) Field(structName, name, field string, constraints []Constraint, value any, fields func(name string) any) bool {
+	var lookup func(string) (reflect.Value, bool)
+	if fields != nil {
+		lookup = func(name string) (reflect.Value, bool) {
+			f := reflect.ValueOf(fields(name))
+			return f, f.IsValid()
+		}
+	}
+	c.v.checkField(c.e, structName, name, field, constraints, reflect.ValueOf(value), lookup)
+	return c.e.Truncated
+}
+
+// Fail records the violations of a field checked by a generated validator and
+// reports whether the validation must stop. failed tells in order whether each
+// of the first constraints is violated, the rules without a check come after
+// them. value is the value of the field.
+func (c *Check) Fail(structName, name, field string, constraints []Constraint, value any, failed ...bool) bool {
+	stop := c.v.bail || hasConstraint(constraints, bail)
+	fieldError := FieldError{Field: field, Struct: structName, Value: fieldValue(reflect.ValueOf(value))}
+	for i, violated := range failed {
+		if !violated {
+			continue
+		}
+		if constraints[i].Kind == required {
+			fieldError.Value = nil
+		}
+		fieldError.Violations = append(fieldError.Violations, constraints[i])
+		if stop {
+			break
+		}
+	}
+	if len(fieldError.Violations) > 0 {
+		c.v.appendFieldError(c.e, fieldError, name, constraints)
+	}
+	return c.e.Truncated
+}
+
+// fieldValue returns the value of a field as reported by the rules checks.
+func fieldValue(fv reflect.Value) any {
+	fv, ok := elem(fv)
+	if !ok || !fv.IsValid() {
+		return nil
+	}
+	t := fv.Type()
+	var value any
+	switch {
+	case isString(t):
+		value, _ = getStringValue(fv)
+	case isInt(t):
+		value, _ = getIntValue(fv)
+	case isUint(t):
+		value, _ = getUintValue(fv)
+	case isFloat(t):
+		value, _ = getFloatValue(fv)
+	case isStringArray(t):
+		if values, ok := getStringArrayValue(fv); ok {
+			value = values
+		}
+	case isIntArray(t):
+		if values, ok := getIntArrayValue(fv); ok {
+			value = values
+		}
+	case isUintArray(t):
+		if values, ok := getUintArrayValue(fv); ok {
+			value = values
+		}
+	case isFloatArray(t):
+		if values, ok := getFloatArrayValue(fv); ok {
+			value = values
+		}
+	}
+	return value
+}
+
+// RuleRegexp returns the regular expression of a named rule such as email, or
+// nil, for the generated validators.
+func RuleRegexp(kind string) *regexp.Regexp {
+	return regexMap[kind]
+}
+
+func (c *Check) Err() error {
+	if len(c.e.FieldsErrors) > 0 {
+		return c.e
+	}
+	return nil
+}
+
+var generatedValidators sync.Map
+
+// RegisterGenerated is called by the code generated by validatorgen, Struct then
+// uses the generated validator of T instead of walking it with reflection.
+func RegisterGenerated[T any](validate func(*T, *Validator) error) {
+	generatedValidators.Store(reflect.TypeOf((*T)(nil)).Elem(), func(p any, v *Validator) error {
+		return validate(p.(*T), v)
+	})
+}
+
+// generated returns the generated validator of t, unless rules were registered
+// for t since they are not known by the generated code.
+func (v *Validator) generated(t reflect.Type) (func(any, *Validator) error, bool) {
+	validate, ok := generatedValidators.Load(t)
+	if !ok || v.hasRules(t) {
+		return nil, false
+	}
+	return validate.(func(any, *Validator) error), true
+}
+
+func (v *Validator) hasRules(t reflect.Type) bool {
+	v.mu.RLock()
+	n := len(v.types)
+	_, ok := v.types[t]
+	v.mu.RUnlock()
+	if n == 0 || ok {
+		return ok
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if ft := t.Field(i); ft.Anonymous && ft.Type.Kind() == reflect.Struct && v.hasRules(ft.Type) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,192 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+const defaultMaxBodySize = 1 << 20
+
+// MaxBodySize limits the size of the request bodies decoded by DecodeJSON, 1MB
+// by default. n <= 0 removes the limit.
+func MaxBodySize(n int64) Option {
+	return func(v *Validator) {
+		v.maxBodySize = n
+	}
+}
+
+// DisallowUnknownFields makes DecodeJSON reject the bodies with fields unknown
+// to the decoded type.
+func DisallowUnknownFields() Option {
+	return func(v *Validator) {
+		v.strictJSON = true
+	}
+}
+
+// DecodeError is returned when a request body cannot be decoded, Status is the
+// HTTP status code to respond with.
+type DecodeError struct {
+	Status int
+	Err    error
+}
+
+func (e *DecodeError) Error() string {
+	return "validate: decode body: " + e.Err.Error()
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeJSON decodes the JSON body of r into a T, a struct or a pointer to a
+// struct, and validates it. The error is a *DecodeError or an *Error.
+func DecodeJSON[T any](r *http.Request) (T, error) {
+	return DecodeJSONWith[T](defaultValidator, r)
+}
+
+func DecodeJSONWith[T any](v *Validator, r *http.Request) (T, error) {
+	return decodeJSON[T](v, nil, r)
+}
+
+// decodeJSON passes w to http.MaxBytesReader when known, so that the server
+// closes the connection after a body larger than the limit.
+func decodeJSON[T any](v *Validator, w http.ResponseWriter, r *http.Request) (T, error) {
+	var value T
+	body := r.Body
+	if v.maxBodySize > 0 {
+		body = http.MaxBytesReader(w, body, v.maxBodySize)
+	}
+	var raw json.RawMessage
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&raw); err != nil {
+		status := http.StatusBadRequest
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			status = http.StatusRequestEntityTooLarge
+		} else if err == io.EOF {
+			err = errors.New("empty body")
+		}
+		return value, &DecodeError{Status: status, Err: err}
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return value, &DecodeError{Status: http.StatusBadRequest, Err: errors.New("body must contain a single JSON value")}
+	}
+	if string(raw) == "null" {
+		return value, &DecodeError{Status: http.StatusBadRequest, Err: errors.New("body must not be null")}
+	}
+
+	decoder = json.NewDecoder(bytes.NewReader(raw))
+	if v.strictJSON {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(&value); err != nil {
+		return value, &DecodeError{Status: http.StatusBadRequest, Err: jsonError(err)}
+	}
+	rv := reflect.ValueOf(&value).Elem()
+	for rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	return value, v.Struct(rv.Addr().Interface())
+}
+
+// bodyError rewords an error of encoding/json, whose message names the Go types
+// and fields, by the JSON fields and types.
+type bodyError struct {
+	message string
+	err     error
+}
+
+func (e *bodyError) Error() string {
+	return e.message
+}
+
+func (e *bodyError) Unwrap() error {
+	return e.err
+}
+
+func jsonError(err error) error {
+	var typeError *json.UnmarshalTypeError
+	if errors.As(err, &typeError) {
+		if typeError.Field == "" {
+			return &bodyError{fmt.Sprintf("body must be %s, got %s", jsonType(typeError.Type), typeError.Value), err}
+		}
+		return &bodyError{fmt.Sprintf("field %q must be %s, got %s", typeError.Field, jsonType(typeError.Type), typeError.Value), err}
+	}
+	if message, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return &bodyError{"unknown field " + message, err}
+	}
+	return err
+}
+
+// jsonType names the JSON values a Go type is decoded from.
+func jsonType(t reflect.Type) string {
+	t = indirect(t)
+	switch {
+	case t.Kind() == reflect.String, t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return "a string"
+	case t.Kind() == reflect.Bool:
+		return "a boolean"
+	case isIntKind(t.Kind()), isUintKind(t.Kind()):
+		return "an integer"
+	case isFloatKind(t.Kind()):
+		return "a number"
+	case t.Kind() == reflect.Slice, t.Kind() == reflect.Array:
+		return "an array"
+	case t.Kind() == reflect.Struct, t.Kind() == reflect.Map:
+		return "an object"
+	}
+	return "a valid value"
+}
+
+// Handler decodes and validates the request body before calling handle, the
+// invalid requests are answered with WriteError.
+func Handler[T any](handle func(w http.ResponseWriter, r *http.Request, value T)) http.Handler {
+	return HandlerWith[T](defaultValidator, handle)
+}
+
+func HandlerWith[T any](v *Validator, handle func(w http.ResponseWriter, r *http.Request, value T)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value, err := decodeJSON[T](v, w, r)
+		if err != nil {
+			WriteError(w, err)
+			return
+		}
+		handle(w, r, value)
+	})
+}
+
+type errorBody struct {
+	Error        string       `json:"error"`
+	FieldsErrors []FieldError `json:"fieldsErrors,omitempty"`
+	Truncated    bool         `json:"truncated,omitempty"`
+}
+
+// WriteError writes err as a JSON body: the validation errors with the status
+// 422 and their fields errors, the decode errors with their status.
+func WriteError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	body := errorBody{Error: http.StatusText(status)}
+	var validationError *Error
+	var decodeError *DecodeError
+	switch {
+	case errors.As(err, &validationError):
+		status = http.StatusUnprocessableEntity
+		body = errorBody{
+			Error:        "validation failed",
+			FieldsErrors: validationError.FieldsErrors,
+			Truncated:    validationError.Truncated,
+		}
+	case errors.As(err, &decodeError):
+		status = decodeError.Status
+		body.Error = fmt.Sprintf("invalid body: %v", decodeError.Err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
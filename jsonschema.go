@@ -0,0 +1,293 @@
+package validator
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// JSONSchema returns the JSON Schema (draft 2020-12) of the struct s built from its
+// validate tags, nested structs are defined in $defs. A struct named like a struct
+// of another package is qualified by its package name, such as pb.Item.
+func JSONSchema(s any) map[string]any {
+	return defaultValidator.JSONSchema(s)
+}
+
+func (v *Validator) JSONSchema(s any) map[string]any {
+	t := indirect(reflect.TypeOf(s))
+	b := &schemaBuilder{
+		v:      v,
+		prefix: "#/$defs/",
+		refs:   map[reflect.Type]string{t: "#"},
+		defs:   map[string]any{},
+	}
+	schema := map[string]any{
+		"$schema": jsonSchemaDraft,
+		"title":   t.Name(),
+	}
+	for key, value := range b.object(t) {
+		schema[key] = value
+	}
+	if len(b.defs) > 0 {
+		schema["$defs"] = b.defs
+	}
+	return schema
+}
+
+type schemaBuilder struct {
+	v      *Validator
+	prefix string
+	refs   map[reflect.Type]string
+	defs   map[string]any
+	// extensions lists the rules without schema equivalent in x-validator-rule.
+	extensions bool
+}
+
+func (b *schemaBuilder) ref(t reflect.Type) map[string]any {
+	if ref, ok := b.refs[t]; ok {
+		return map[string]any{"$ref": ref}
+	}
+	if t.Name() == "" {
+		return b.object(t)
+	}
+	name := uniqueName(t, ".", func(name string) bool {
+		_, ok := b.defs[name]
+		return ok
+	})
+	ref := b.prefix + name
+	b.refs[t] = ref
+	// reserves the name for the structs defined by object
+	b.defs[name] = nil
+	b.defs[name] = b.object(t)
+	return map[string]any{"$ref": ref}
+}
+
+// uniqueName returns the name of t, qualified by its package name joined with
+// sep when taken reports that the name is used by a struct of another package.
+func uniqueName(t reflect.Type, sep string, taken func(name string) bool) string {
+	name := t.Name()
+	if !taken(name) {
+		return name
+	}
+	qualified := path.Base(t.PkgPath()) + sep + name
+	name = qualified
+	for i := 2; taken(name); i++ {
+		name = fmt.Sprintf("%s%d", qualified, i)
+	}
+	return name
+}
+
+func (b *schemaBuilder) object(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	requiredFields := []string{}
+	b.properties(t, b.v.scopes(nil, t, ""), properties, &requiredFields)
+	schema := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(requiredFields) > 0 {
+		schema["required"] = requiredFields
+	}
+	return schema
+}
+
+func (b *schemaBuilder) properties(t reflect.Type, scopes []rulesScope, properties map[string]any, requiredFields *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		ft := t.Field(i)
+		if !ft.IsExported() {
+			continue
+		}
+		name := fieldName(ft)
+		if name == "-" {
+			continue
+		}
+		if ft.Anonymous && ft.Type.Kind() == reflect.Struct && name == ft.Name {
+			b.properties(ft.Type, b.v.scopes(scopes, ft.Type, ft.Name), properties, requiredFields)
+			continue
+		}
+		constraints, _ := b.v.fieldConstraints(scopes, ft)
+		property := b.typeSchema(ft.Type)
+		check := &rulesCheck{structName: t.Name(), name: ft.Name}
+		for _, constraint := range constraints {
+			if constraint.Kind == required {
+				*requiredFields = append(*requiredFields, name)
+				continue
+			}
+			b.constraint(property, ft.Type, constraint, check)
+		}
+		if value, ok := b.v.fieldDefault(scopes, ft); ok {
+			dv, ok := defaultValue(indirect(ft.Type), value)
+			if !ok {
+				check.invalidParam(Constraint{Tag: defaultRule + "=" + value, Kind: defaultRule, Param: value})
+			}
+			property["default"] = dv.Interface()
+		}
+		properties[name] = property
+	}
+}
+
+func (b *schemaBuilder) typeSchema(t reflect.Type) map[string]any {
+	t = indirect(t)
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t.Kind() == reflect.String:
+		return map[string]any{"type": "string"}
+	case t.Kind() == reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case isIntKind(t.Kind()) || isUintKind(t.Kind()):
+		return map[string]any{"type": "integer"}
+	case isFloatKind(t.Kind()):
+		return map[string]any{"type": "number"}
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return map[string]any{"type": "string", "contentEncoding": "base64"}
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		return map[string]any{"type": "array", "items": b.typeSchema(t.Elem())}
+	case t.Kind() == reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": b.typeSchema(t.Elem())}
+	case t.Kind() == reflect.Struct:
+		return b.ref(t)
+	}
+	return map[string]any{}
+}
+
+func (b *schemaBuilder) constraint(schema map[string]any, t reflect.Type, constraint Constraint, check *rulesCheck) {
+	t = indirect(t)
+	if exp, ok := regexMap[constraint.Kind]; ok {
+		switch {
+		case constraint.Kind == email:
+			schema["format"] = "email"
+		case constraint.Kind == url:
+			schema["format"] = "uri"
+		case constraint.Kind == cron && b.extensions:
+			b.extension(schema, constraint)
+		default:
+			b.pattern(schema, exp.String(), constraint)
+		}
+		return
+	}
+	switch constraint.Kind {
+	case minLen, maxLen, length:
+		param, ok := getIntParam(constraint.Param)
+		if !ok {
+			check.invalidParam(constraint)
+		}
+		if constraint.Kind != maxLen {
+			schema["minLength"] = param
+		}
+		if constraint.Kind != minLen {
+			schema["maxLength"] = param
+		}
+	case min, max:
+		keyword := "minimum"
+		if constraint.Kind == max {
+			keyword = "maximum"
+		}
+		var param any
+		var ok bool
+		switch {
+		case isIntKind(t.Kind()):
+			param, ok = getIntParam(constraint.Param)
+		case isUintKind(t.Kind()):
+			param, ok = getUintParam(constraint.Param)
+		default:
+			param, ok = getFloatParam(constraint.Param)
+		}
+		if !ok {
+			check.invalidParam(constraint)
+		}
+		schema[keyword] = param
+	case match:
+		param, ok := getStringParam(constraint.Param)
+		if !ok {
+			check.invalidParam(constraint)
+		}
+		b.pattern(schema, param, constraint)
+	case in, oneOf, out, include, exclude:
+		values := listParam(t, constraint, check)
+		items, _ := schema["items"].(map[string]any)
+		switch {
+		case constraint.Kind == in && items != nil:
+			items["enum"] = values
+		case constraint.Kind == in || constraint.Kind == oneOf:
+			schema["enum"] = values
+		case constraint.Kind == out && items != nil:
+			items["not"] = map[string]any{"enum": values}
+		case constraint.Kind == out:
+			schema["not"] = map[string]any{"enum": values}
+		case constraint.Kind == include:
+			all := []any{}
+			for _, value := range values {
+				all = append(all, map[string]any{"contains": map[string]any{"const": value}})
+			}
+			schema["allOf"] = all
+		case constraint.Kind == exclude:
+			schema["not"] = map[string]any{"contains": map[string]any{"enum": values}}
+		}
+	case required, sensitive, redact, bail, defaultRule:
+	default:
+		if b.extensions {
+			b.extension(schema, constraint)
+		}
+	}
+}
+
+func (b *schemaBuilder) extension(schema map[string]any, constraint Constraint) {
+	rules, _ := schema["x-validator-rule"].([]string)
+	schema["x-validator-rule"] = append(rules, constraint.Tag)
+}
+
+func listParam(t reflect.Type, constraint Constraint, check *rulesCheck) []any {
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	values := []any{}
+	var ok bool
+	switch {
+	case isIntKind(t.Kind()):
+		var param []int64
+		param, ok = getIntListParam(constraint.Param)
+		for _, p := range param {
+			values = append(values, p)
+		}
+	case isUintKind(t.Kind()):
+		var param []uint64
+		param, ok = getUintListParam(constraint.Param)
+		for _, p := range param {
+			values = append(values, p)
+		}
+	case isFloatKind(t.Kind()):
+		var param []float64
+		param, ok = getFloatListParam(constraint.Param)
+		for _, p := range param {
+			values = append(values, p)
+		}
+	default:
+		param := getOneOfString(constraint.Param)
+		ok = param != nil
+		for _, p := range param {
+			values = append(values, p)
+		}
+	}
+	if !ok {
+		check.invalidParam(constraint)
+	}
+	return values
+}
+
+// pattern sets the pattern keyword from p translated to ECMAScript, the patterns
+// relying on flags have no equivalent and are left to the extension.
+func (b *schemaBuilder) pattern(schema map[string]any, p string, constraint Constraint) {
+	source, flags := jsRegexp(p)
+	if strings.Trim(flags, "u") == "" {
+		schema["pattern"] = source
+	} else if b.extensions {
+		b.extension(schema, constraint)
+	}
+}
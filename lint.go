@@ -0,0 +1,214 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LintField describes the field a validate tag is attached to, for LintTag.
+type LintField struct {
+	// Kind is the kind of the field, pointers are dereferenced.
+	Kind reflect.Kind
+	// Elem is the kind of the elements of slice fields.
+	Elem reflect.Kind
+	// HasField reports whether the struct of the field has a field named name,
+	// it is used to check the params of the comparison rules.
+	HasField func(name string) bool
+}
+
+const (
+	paramNone = iota
+	paramInt
+	paramNumber
+	paramList
+	paramRegexp
+	paramField
+	paramValue
+)
+
+type ruleSpec struct {
+	param   int
+	strings bool
+	numbers bool
+	slices  bool
+	any     bool
+}
+
+var ruleSpecs = map[string]ruleSpec{
+	required:    {any: true},
+	sensitive:   {any: true},
+	redact:      {any: true},
+	bail:        {any: true},
+	defaultRule: {param: paramValue, any: true},
+	min:         {param: paramNumber, numbers: true},
+	max:         {param: paramNumber, numbers: true},
+	length:      {param: paramInt, strings: true},
+	minLen:      {param: paramInt, strings: true},
+	maxLen:      {param: paramInt, strings: true},
+	match:       {param: paramRegexp, strings: true},
+	oneOf:       {param: paramList, strings: true},
+	in:          {param: paramList, strings: true, slices: true},
+	out:         {param: paramList, strings: true, slices: true},
+	include:     {param: paramList, slices: true},
+	exclude:     {param: paramList, slices: true},
+	eqField:     {param: paramField, any: true},
+	neField:     {param: paramField, any: true},
+	gtField:     {param: paramField, any: true},
+	gteField:    {param: paramField, any: true},
+	ltField:     {param: paramField, any: true},
+	lteField:    {param: paramField, any: true},
+}
+
+func init() {
+	for kind := range regexMap {
+		ruleSpecs[kind] = ruleSpec{strings: true}
+	}
+}
+
+// LintTag reports the unknown rules, the invalid params and the rules which do
+// not apply to the kind of field.
+func LintTag(tag string, field LintField) []error {
+	errs := []error{}
+	for _, constraint := range parseConstraints(tag) {
+		if err := lintConstraint(constraint, field); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// LintModifiers reports the unknown modifiers of a mod or sanitize tag, the
+// modifiers registered by the program, such as nfc, are passed as known.
+func LintModifiers(tag string, known ...string) []error {
+	errs := []error{}
+	for _, mod := range parseConstraints(tag) {
+		if err := lintModifier(mod.Kind, known); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func lintModifier(name string, known []string) error {
+	if name == "" {
+		return fmt.Errorf("empty modifier in tag")
+	}
+	if _, ok := modifier(name); ok {
+		return nil
+	}
+	for _, k := range known {
+		if k == name {
+			return nil
+		}
+	}
+	modifiersMu.RLock()
+	defer modifiersMu.RUnlock()
+	for k := range modifiers {
+		if strings.EqualFold(k, name) {
+			return fmt.Errorf("unknown modifier %q, did you mean %q", name, k)
+		}
+	}
+	return unknownModifier(name)
+}
+
+func lintConstraint(constraint Constraint, field LintField) error {
+	spec, ok := ruleSpecs[constraint.Kind]
+	if !ok {
+		if constraint.Kind == "" {
+			return fmt.Errorf("empty rule in tag")
+		}
+		for kind := range ruleSpecs {
+			if strings.EqualFold(kind, constraint.Kind) {
+				return fmt.Errorf("unknown rule %q, did you mean %q", constraint.Kind, kind)
+			}
+		}
+		return fmt.Errorf("unknown rule %q", constraint.Kind)
+	}
+
+	isSlice := field.Kind == reflect.Slice && (field.Elem == reflect.String || isIntKind(field.Elem) || isUintKind(field.Elem) || isFloatKind(field.Elem))
+	isNumber := isIntKind(field.Kind) || isUintKind(field.Kind) || isFloatKind(field.Kind)
+	if !spec.any && !(spec.strings && field.Kind == reflect.String) && !(spec.numbers && isNumber) && !(spec.slices && isSlice) {
+		kind := field.Kind.String()
+		if field.Kind == reflect.Slice {
+			kind = "[]" + field.Elem.String()
+		}
+		return fmt.Errorf("rule %q does not apply to %s fields", constraint.Kind, kind)
+	}
+
+	param, hasParam := getStringParam(constraint.Param)
+	if spec.param == paramNone {
+		if hasParam {
+			return fmt.Errorf("rule %q does not take a param", constraint.Kind)
+		}
+		return nil
+	}
+	if !hasParam || param == "" {
+		return fmt.Errorf("rule %q expects a param", constraint.Kind)
+	}
+
+	kind := field.Kind
+	if kind == reflect.Slice {
+		kind = field.Elem
+	}
+	ok = true
+	switch spec.param {
+	case paramInt:
+		_, ok = getIntParam(param)
+	case paramNumber:
+		switch {
+		case isIntKind(kind):
+			_, ok = getIntParam(param)
+		case isUintKind(kind):
+			_, ok = getUintParam(param)
+		default:
+			_, ok = getFloatParam(param)
+		}
+	case paramList:
+		switch {
+		case isIntKind(kind):
+			_, ok = getIntListParam(param)
+		case isUintKind(kind):
+			_, ok = getUintListParam(param)
+		case isFloatKind(kind):
+			_, ok = getFloatListParam(param)
+		}
+	case paramRegexp:
+		if _, err := compileMatch(param); err != nil {
+			return fmt.Errorf("rule %q invalid regexp: %v", constraint.Kind, err)
+		}
+	case paramValue:
+		items := []string{param}
+		if field.Kind == reflect.Slice {
+			items = strings.Split(param, ",")
+		}
+		for _, item := range items {
+			item = strings.TrimSpace(item)
+			switch {
+			case isIntKind(kind):
+				_, err := strconv.ParseInt(item, 10, 64)
+				_, durationErr := time.ParseDuration(item)
+				ok = ok && (err == nil || kind == reflect.Int64 && durationErr == nil)
+			case isUintKind(kind):
+				_, err := strconv.ParseUint(item, 10, 64)
+				ok = ok && err == nil
+			case isFloatKind(kind):
+				_, err := strconv.ParseFloat(item, 64)
+				ok = ok && err == nil
+			case kind == reflect.Bool:
+				_, err := strconv.ParseBool(item)
+				ok = ok && err == nil
+			}
+		}
+	case paramField:
+		if field.HasField != nil && !field.HasField(param) {
+			return fmt.Errorf("rule %q refers to the unknown field %q", constraint.Kind, param)
+		}
+	}
+	if !ok {
+		return fmt.Errorf("rule %q invalid param %q", constraint.Kind, param)
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Map validates data against rules keyed by path, with the tag syntax. The nested
+// maps are addressed by dotted keys and the items of the arrays by [*] or by
+// their index, such as "items[*].sku". The fields errors are named by the paths
+// of the values, such as "items[2].sku", and the comparison rules refer to the
+// keys of the same map. The values which do not have the type required by their
+// rules, such as a number with minLen, are type violations.
+func Map(data map[string]any, rules map[string]string) error {
+	return defaultValidator.Map(data, rules)
+}
+
+func (v *Validator) Map(data map[string]any, rules map[string]string) error {
+	keys := []string{}
+	for key := range rules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	e := &Error{}
+	root := reflect.ValueOf(data)
+	for _, key := range keys {
+		constraints := parseConstraints(rules[key])
+		steps, ok := parsePath(key)
+		if !ok {
+			panic(fmt.Sprintf("validate: map rules invalid key %s", key))
+		}
+		walkPath(root, "", steps, func(path string, value reflect.Value) {
+			if e.Truncated {
+				return
+			}
+			value = mapValue(value)
+			if typeName, ok := mapType(value, constraints); !ok {
+				v.appendFieldError(e, FieldError{
+					Field:      path,
+					Value:      value.Interface(),
+					Violations: []Constraint{{Tag: typeRule + "=" + typeName, Kind: typeRule, Param: typeName}},
+				}, path, constraints)
+				return
+			}
+			v.checkField(e, "", path, path, constraints, value, func(name string) (reflect.Value, bool) {
+				sibling := name
+				if i := strings.LastIndex(path, "."); i >= 0 {
+					sibling = path[:i+1] + name
+				}
+				steps, ok := parsePath(sibling)
+				var other reflect.Value
+				if ok {
+					walkPath(root, "", steps, func(_ string, value reflect.Value) {
+						other = mapValue(value)
+					})
+				}
+				return other, true
+			})
+		})
+	}
+	if len(e.FieldsErrors) > 0 {
+		return e
+	}
+	return nil
+}
+
+// mapType returns the type required of value by the constraints, and whether
+// value has it. Missing values are left to the required rule.
+func mapType(value reflect.Value, constraints []Constraint) (string, bool) {
+	if !value.IsValid() {
+		return "", true
+	}
+	kind := value.Kind()
+	isList := kind == reflect.Slice || kind == reflect.Array
+	for _, constraint := range constraints {
+		_, isRegexp := regexMap[constraint.Kind]
+		switch {
+		case isRegexp, constraint.Kind == minLen, constraint.Kind == maxLen, constraint.Kind == length, constraint.Kind == match, constraint.Kind == oneOf:
+			if kind != reflect.String {
+				return "string", false
+			}
+		case constraint.Kind == in, constraint.Kind == out:
+			if kind != reflect.String && !isList {
+				return "string", false
+			}
+		case constraint.Kind == include, constraint.Kind == exclude:
+			if !isList {
+				return "array", false
+			}
+		case constraint.Kind == min, constraint.Kind == max:
+			if !isIntKind(kind) && !isUintKind(kind) && !isFloatKind(kind) {
+				return "number", false
+			}
+		}
+	}
+	return "", true
+}
+
+// pathStep is a key of a map or an index of an array, -1 for all the items.
+type pathStep struct {
+	key   string
+	index int
+	item  bool
+}
+
+func parsePath(key string) ([]pathStep, bool) {
+	steps := []pathStep{}
+	for _, segment := range strings.Split(key, ".") {
+		name, indexes, _ := strings.Cut(segment, "[")
+		if name == "" {
+			return nil, false
+		}
+		steps = append(steps, pathStep{key: name})
+		if indexes == "" {
+			continue
+		}
+		for _, index := range strings.Split(strings.TrimSuffix(indexes, "]"), "][") {
+			if index == "*" {
+				steps = append(steps, pathStep{index: -1, item: true})
+				continue
+			}
+			i, err := strconv.Atoi(index)
+			if err != nil || i < 0 {
+				return nil, false
+			}
+			steps = append(steps, pathStep{index: i, item: true})
+		}
+	}
+	return steps, true
+}
+
+// walkPath calls fn with the values at the end of steps, the missing ones are
+// invalid values. The items of missing arrays are skipped.
+func walkPath(value reflect.Value, path string, steps []pathStep, fn func(path string, value reflect.Value)) {
+	if len(steps) == 0 {
+		fn(path, value)
+		return
+	}
+	step := steps[0]
+	value = unwrap(value)
+	if !step.item {
+		if path != "" {
+			path += "."
+		}
+		path += step.key
+		var child reflect.Value
+		if value.IsValid() && value.Kind() == reflect.Map && value.Type().Key().Kind() == reflect.String {
+			child = value.MapIndex(reflect.ValueOf(step.key).Convert(value.Type().Key()))
+		}
+		walkPath(child, path, steps[1:], fn)
+		return
+	}
+	if !value.IsValid() || value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		if step.index >= 0 {
+			walkPath(reflect.Value{}, fmt.Sprintf("%s[%d]", path, step.index), steps[1:], fn)
+		}
+		return
+	}
+	for i := 0; i < value.Len(); i++ {
+		if step.index < 0 || step.index == i {
+			walkPath(value.Index(i), fmt.Sprintf("%s[%d]", path, i), steps[1:], fn)
+		}
+	}
+	if step.index >= value.Len() {
+		walkPath(reflect.Value{}, fmt.Sprintf("%s[%d]", path, step.index), steps[1:], fn)
+	}
+}
+
+// mapValue unwraps the interfaces of decoded data, the arrays of strings or
+// numbers become typed slices so that the list rules apply to them.
+func mapValue(value reflect.Value) reflect.Value {
+	value = unwrap(value)
+	if !value.IsValid() || value.Kind() != reflect.Slice || value.Type().Elem().Kind() != reflect.Interface || value.Len() == 0 {
+		return value
+	}
+	first := mapValue(value.Index(0))
+	if !first.IsValid() || (first.Kind() != reflect.String && first.Kind() != reflect.Float64) {
+		return value
+	}
+	slice := reflect.MakeSlice(reflect.SliceOf(first.Type()), value.Len(), value.Len())
+	for i := 0; i < value.Len(); i++ {
+		item := mapValue(value.Index(i))
+		if !item.IsValid() || item.Type() != first.Type() {
+			return value
+		}
+		slice.Index(i).Set(item)
+	}
+	return slice
+}
+
+func unwrap(value reflect.Value) reflect.Value {
+	for value.IsValid() && (value.Kind() == reflect.Interface || value.Kind() == reflect.Pointer) {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
@@ -0,0 +1,76 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var messages = map[string]string{
+	required:     "is required",
+	alpha:        "must contain only letters",
+	url:          "must be an http, https or ftp URL",
+	alphaSpace:   "must contain only words of letters separated by single spaces",
+	alphaNumeric: "must contain only letters and digits",
+	numeric:      "must be a decimal number",
+	number:       "must contain only digits",
+	hexadecimal:  "must be a hexadecimal number",
+	hexColor:     "must be a hexadecimal color",
+	rgb:          "must be an rgb() color",
+	rgba:         "must be an rgba() color",
+	hsl:          "must be an hsl() color",
+	hsla:         "must be an hsla() color",
+	email:        "must be an email address",
+	cron:         "must be a cron expression",
+	min:          "must be at least %s",
+	max:          "must be at most %s",
+	length:       "must be exactly %s bytes long",
+	minLen:       "must be at least %s bytes long",
+	maxLen:       "must be at most %s bytes long",
+	match:        "must match %s",
+	oneOf:        "must be one of %s",
+	in:           "must be among %s",
+	out:          "must not be among %s",
+	include:      "must include %s",
+	exclude:      "must not include %s",
+	sensitive:    "is redacted from the errors",
+	redact:       "is redacted from the errors",
+	bail:         "stops at the first failed rule",
+	eqField:      "must be equal to %s",
+	neField:      "must be different from %s",
+	gtField:      "must be greater than %s",
+	gteField:     "must be greater than or equal to %s",
+	ltField:      "must be less than %s",
+	lteField:     "must be less than or equal to %s",
+	typeRule:     "must be a valid %s",
+	defaultRule:  "defaults to %s",
+}
+
+// Message describes the rule in plain language, such as "must be at least 18".
+func (c Constraint) Message() string {
+	message, ok := messages[c.Kind]
+	if !ok {
+		return "must satisfy " + c.Tag
+	}
+	if !strings.Contains(message, "%s") {
+		return message
+	}
+	return fmt.Sprintf(message, c.param())
+}
+
+func (c Constraint) param() string {
+	switch c.Kind {
+	case oneOf, in, out, include, exclude:
+		if param, ok := c.Param.(string); ok {
+			return strings.Join(getOneOfString(param), ", ")
+		}
+		if rv := reflect.ValueOf(c.Param); rv.Kind() == reflect.Slice {
+			items := make([]string, rv.Len())
+			for i := range items {
+				items[i] = fmt.Sprint(rv.Index(i).Interface())
+			}
+			return strings.Join(items, ", ")
+		}
+	}
+	return fmt.Sprint(c.Param)
+}
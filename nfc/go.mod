@@ -0,0 +1,10 @@
+module github.com/oSethoum/validator/nfc
+
+go 1.20
+
+require (
+	github.com/oSethoum/validator v0.0.0-00010101000000-000000000000
+	golang.org/x/text v0.22.0
+)
+
+replace github.com/oSethoum/validator => ../
@@ -0,0 +1,15 @@
+// Package nfc registers the nfc modifier of the mod tag, which normalizes strings
+// to the Unicode normalization form C. It is kept apart so that the validator
+// package has no dependencies.
+//
+//	import _ "github.com/oSethoum/validator/nfc"
+package nfc
+
+import (
+	"github.com/oSethoum/validator"
+	"golang.org/x/text/unicode/norm"
+)
+
+func init() {
+	validator.RegisterModifier("nfc", norm.NFC.String)
+}
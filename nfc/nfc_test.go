@@ -0,0 +1,22 @@
+package nfc_test
+
+import (
+	"testing"
+
+	"github.com/oSethoum/validator"
+	_ "github.com/oSethoum/validator/nfc"
+)
+
+type Profile struct {
+	Bio string `mod:"stripTags;nfc" validate:"len=5"`
+}
+
+func TestNFC(t *testing.T) {
+	profile := Profile{Bio: "<b>Cafe\u0301</b>"}
+	if err := validator.Struct(&profile); err != nil {
+		t.Fatalf("expected the composed bio to be valid, got %v", err)
+	}
+	if profile.Bio != "Caf\u00e9" {
+		t.Errorf("expected the composed é, got %q", profile.Bio)
+	}
+}
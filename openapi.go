@@ -0,0 +1,75 @@
+package validator
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// OpenAPI generates the components.schemas of an OpenAPI 3.1 document from the
+// validate tags of the registered types.
+type OpenAPI struct {
+	v       *Validator
+	types   []reflect.Type
+	title   string
+	version string
+}
+
+func NewOpenAPI(values ...any) *OpenAPI {
+	return defaultValidator.OpenAPI(values...)
+}
+
+func (v *Validator) OpenAPI(values ...any) *OpenAPI {
+	return (&OpenAPI{v: v, title: "API", version: "0.0.0"}).Register(values...)
+}
+
+// Info sets the title and the version of the info object of the document, they
+// default to API and 0.0.0.
+func (o *OpenAPI) Info(title, version string) *OpenAPI {
+	o.title = title
+	o.version = version
+	return o
+}
+
+func (o *OpenAPI) Register(values ...any) *OpenAPI {
+	for _, value := range values {
+		o.types = append(o.types, indirect(reflect.TypeOf(value)))
+	}
+	return o
+}
+
+// Components returns the components object, the rules without OpenAPI
+// equivalent are listed in the x-validator-rule extension of their property.
+func (o *OpenAPI) Components() map[string]any {
+	b := &schemaBuilder{
+		v:          o.v,
+		prefix:     "#/components/schemas/",
+		refs:       map[reflect.Type]string{},
+		defs:       map[string]any{},
+		extensions: true,
+	}
+	for _, t := range o.types {
+		b.ref(t)
+	}
+	return map[string]any{
+		"schemas": b.defs,
+	}
+}
+
+func (o *OpenAPI) document() map[string]any {
+	return map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":   o.title,
+			"version": o.version,
+		},
+		"components": o.Components(),
+	}
+}
+
+func (o *OpenAPI) JSON() ([]byte, error) {
+	return json.MarshalIndent(o.document(), "", "  ")
+}
+
+func (o *OpenAPI) YAML() ([]byte, error) {
+	return marshalYAML(o.document()), nil
+}
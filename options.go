@@ -0,0 +1,25 @@
+package validator
+
+// StopOnFirstError makes Struct return as soon as the first violation is found.
+func StopOnFirstError() Option {
+	return func(v *Validator) {
+		v.maxErrors = 1
+		v.bail = true
+	}
+}
+
+// MaxErrors stops the validation once n fields errors are collected, the returned
+// error is then flagged as Truncated.
+func MaxErrors(n int) Option {
+	return func(v *Validator) {
+		v.maxErrors = n
+	}
+}
+
+// Bail stops evaluating the rules of a field after its first violation, the same
+// can be done per field with the bail tag.
+func Bail() Option {
+	return func(v *Validator) {
+		v.bail = true
+	}
+}
@@ -0,0 +1,107 @@
+package validator
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+)
+
+type ValuePolicy int
+
+const (
+	// IncludeNonSensitive keeps values in field errors except for sensitive fields.
+	IncludeNonSensitive ValuePolicy = iota
+	// IncludeNone never includes values in field errors.
+	IncludeNone
+	// HashSensitive replaces the values of sensitive fields with an HMAC-SHA256
+	// digest keyed by HashKey, so that repeated failures can be correlated without
+	// exposing the value. Without HashKey a random key is drawn by New, the digests
+	// then only correlate the errors of the same Validator.
+	HashSensitive
+)
+
+const redactedValue = "[redacted]"
+
+var defaultSensitiveNames = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)passw(or)?d|passphrase`),
+	regexp.MustCompile(`(?i)secret`),
+	regexp.MustCompile(`(?i)token`),
+	regexp.MustCompile(`(?i)api_?key`),
+	regexp.MustCompile(`(?i)card_?number`),
+	regexp.MustCompile(`(?i)cvv|cvc`),
+}
+
+func WithValuePolicy(policy ValuePolicy) Option {
+	return func(v *Validator) {
+		v.valuePolicy = policy
+	}
+}
+
+// HashKey sets the key of the HashSensitive digests, validators sharing a key
+// produce the same digests. The key should be kept as secret as the values since
+// short values such as PINs are easily guessed from their digest and the key.
+func HashKey(key []byte) Option {
+	return func(v *Validator) {
+		v.hashKey = key
+	}
+}
+
+// SensitiveNames replaces the field name patterns that are redacted automatically,
+// the patterns are matched against both the go and the json field names.
+func SensitiveNames(patterns ...string) Option {
+	return func(v *Validator) {
+		v.sensitiveNames = []*regexp.Regexp{}
+		for _, pattern := range patterns {
+			v.sensitiveNames = append(v.sensitiveNames, regexp.MustCompile(pattern))
+		}
+	}
+}
+
+func (v *Validator) isSensitive(name string, field string, constraints []Constraint) bool {
+	for _, constraint := range constraints {
+		if constraint.Kind == sensitive || constraint.Kind == redact {
+			return true
+		}
+	}
+	for _, exp := range v.sensitiveNames {
+		if (name != "" && exp.MatchString(name)) || (field != "" && exp.MatchString(field)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *Validator) redact(fieldError *FieldError, name string, constraints []Constraint) {
+	if fieldError.Value == nil {
+		return
+	}
+	switch {
+	case v.valuePolicy == IncludeNone:
+		fieldError.Value = nil
+		fieldError.Redacted = true
+	case !v.isSensitive(name, fieldError.Field, constraints):
+	case v.valuePolicy == HashSensitive:
+		fieldError.Value = hashValue(v.hashKey, fieldError.Value)
+		fieldError.Redacted = true
+	default:
+		fieldError.Value = nil
+		fieldError.Redacted = true
+	}
+}
+
+func hashValue(key []byte, value any) string {
+	mac := hmac.New(sha256.New, key)
+	fmt.Fprintf(mac, "%v", value)
+	return "hmac-sha256:" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func randomKey() []byte {
+	key := make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		panic(fmt.Sprintf("validate: hash key: %v", err))
+	}
+	return key
+}
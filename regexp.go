@@ -1,6 +1,10 @@
 package validator
 
-import "regexp"
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
 
 const (
 	alphaRegexString        = "^[a-zA-Z]+$"
@@ -35,3 +39,106 @@ var regexMap = map[string]*regexp.Regexp{
 	email:        regexp.MustCompile(emailRegexString),
 	cron:         regexp.MustCompile(cronRegexString),
 }
+
+var matchCache sync.Map
+
+// compileMatch compiles the param of a match rule once per pattern.
+func compileMatch(pattern string) (*regexp.Regexp, error) {
+	if exp, ok := matchCache.Load(pattern); ok {
+		return exp.(*regexp.Regexp), nil
+	}
+	exp, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	matchCache.Store(pattern, exp)
+	return exp, nil
+}
+
+var posixClasses = map[string]string{
+	"alnum":  "0-9A-Za-z",
+	"alpha":  "A-Za-z",
+	"ascii":  "\\x00-\\x7F",
+	"blank":  "\\t ",
+	"cntrl":  "\\x00-\\x1F\\x7F",
+	"digit":  "0-9",
+	"graph":  "!-~",
+	"lower":  "a-z",
+	"print":  " -~",
+	"punct":  "!-\\/:-@\\[-`{-~",
+	"space":  "\\t\\n\\v\\f\\r ",
+	"upper":  "A-Z",
+	"word":   "0-9A-Za-z_",
+	"xdigit": "0-9A-Fa-f",
+}
+
+// jsRegexp translates a go regular expression to the ECMAScript syntax used by
+// browsers and JSON Schema, a leading flags group is returned as flags.
+func jsRegexp(pattern string) (source string, flags string) {
+	if strings.HasPrefix(pattern, "(?") {
+		if end := strings.Index(pattern, ")"); end > 2 && strings.Trim(pattern[2:end], "imsU") == "" {
+			flags = strings.ReplaceAll(pattern[2:end], "U", "")
+			pattern = pattern[end+1:]
+		}
+	}
+	unicode := false
+	b := strings.Builder{}
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		rest := pattern[i:]
+		switch {
+		case c == '\\' && i+1 < len(pattern):
+			next := pattern[i+1]
+			switch {
+			case next == 'x' && strings.HasPrefix(rest[2:], "{"):
+				end := strings.Index(rest, "}")
+				hex := strings.TrimLeft(rest[3:end], "0")
+				if len(hex) <= 4 {
+					b.WriteString("\\u" + strings.Repeat("0", 4-len(hex)) + hex)
+				} else {
+					b.WriteString("\\u{" + hex + "}")
+					unicode = true
+				}
+				i += end
+			case next == 'A':
+				b.WriteString("^")
+				i++
+			case next == 'z':
+				b.WriteString("$")
+				i++
+			case (next == 'p' || next == 'P') && i+2 < len(pattern) && pattern[i+2] != '{':
+				b.WriteString("\\" + string(next) + "{" + string(pattern[i+2]) + "}")
+				unicode = true
+				i += 2
+			case next == 'p' || next == 'P':
+				unicode = true
+				b.WriteString(rest[:2])
+				i++
+			default:
+				b.WriteString(rest[:2])
+				i++
+			}
+		case strings.HasPrefix(rest, "(?P<"):
+			b.WriteString("(?<")
+			i += 3
+		case strings.HasPrefix(rest, "[:"):
+			end := strings.Index(rest, ":]")
+			class, ok := "", false
+			if end > 2 {
+				class, ok = posixClasses[rest[2:end]]
+			}
+			if ok {
+				b.WriteString(class)
+				i += end + 1
+			} else {
+				b.WriteByte(c)
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	if unicode {
+		flags += "u"
+	}
+	return b.String(), flags
+}
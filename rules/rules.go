@@ -0,0 +1,74 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oSethoum/validator"
+)
+
+type number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+type item interface {
+	~string | number
+}
+
+func rule(kind string) validator.Constraint {
+	return validator.Constraint{Tag: kind, Kind: kind}
+}
+
+func ruleWith(kind string, param string) validator.Constraint {
+	return validator.Constraint{Tag: kind + "=" + param, Kind: kind, Param: param}
+}
+
+func list[T item](values []T) string {
+	ss := make([]string, len(values))
+	for i, value := range values {
+		ss[i] = fmt.Sprint(value)
+	}
+	return strings.Join(ss, ",")
+}
+
+func Required() validator.Constraint     { return rule("required") }
+func Alpha() validator.Constraint        { return rule("alpha") }
+func URL() validator.Constraint          { return rule("url") }
+func AlphaSpace() validator.Constraint   { return rule("alphaSpace") }
+func AlphaNumeric() validator.Constraint { return rule("alphaNumeric") }
+func Numeric() validator.Constraint      { return rule("numeric") }
+func Number() validator.Constraint       { return rule("number") }
+func Hexadecimal() validator.Constraint  { return rule("hexadecimal") }
+func HexColor() validator.Constraint     { return rule("hexColor") }
+func RGB() validator.Constraint          { return rule("rgb") }
+func RGBA() validator.Constraint         { return rule("rgba") }
+func HSL() validator.Constraint          { return rule("hsl") }
+func HSLA() validator.Constraint         { return rule("hsla") }
+func Email() validator.Constraint        { return rule("email") }
+func Cron() validator.Constraint         { return rule("cron") }
+func Sensitive() validator.Constraint    { return rule("sensitive") }
+func Bail() validator.Constraint         { return rule("bail") }
+
+func Min[T number](n T) validator.Constraint { return ruleWith("min", fmt.Sprint(n)) }
+func Max[T number](n T) validator.Constraint { return ruleWith("max", fmt.Sprint(n)) }
+
+func Len(n int) validator.Constraint    { return ruleWith("len", fmt.Sprint(n)) }
+func MinLen(n int) validator.Constraint { return ruleWith("minLen", fmt.Sprint(n)) }
+func MaxLen(n int) validator.Constraint { return ruleWith("maxLen", fmt.Sprint(n)) }
+
+func Match(pattern string) validator.Constraint { return ruleWith("match", pattern) }
+
+func OneOf[T item](values ...T) validator.Constraint   { return ruleWith("oneOf", list(values)) }
+func In[T item](values ...T) validator.Constraint      { return ruleWith("in", list(values)) }
+func Out[T item](values ...T) validator.Constraint     { return ruleWith("out", list(values)) }
+func Include[T item](values ...T) validator.Constraint { return ruleWith("include", list(values)) }
+func Exclude[T item](values ...T) validator.Constraint { return ruleWith("exclude", list(values)) }
+
+func EqField(field string) validator.Constraint  { return ruleWith("eqField", field) }
+func NeField(field string) validator.Constraint  { return ruleWith("neField", field) }
+func GtField(field string) validator.Constraint  { return ruleWith("gtField", field) }
+func GteField(field string) validator.Constraint { return ruleWith("gteField", field) }
+func LtField(field string) validator.Constraint  { return ruleWith("ltField", field) }
+func LteField(field string) validator.Constraint { return ruleWith("lteField", field) }
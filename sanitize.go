@@ -0,0 +1,176 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+var (
+	modifiersMu sync.RWMutex
+	modifiers   = map[string]func(string) string{
+		"trim":           strings.TrimSpace,
+		"lower":          strings.ToLower,
+		"upper":          strings.ToUpper,
+		"title":          title,
+		"collapseSpaces": collapseSpaces,
+		"stripTags":      stripTags,
+	}
+)
+
+var (
+	spacesRegexp = regexp.MustCompile(`\s+`)
+	tagsRegexp   = regexp.MustCompile(`<[^>]*>`)
+)
+
+// RegisterModifier makes fn available to the mod tag under name, replacing the
+// modifier with the same name if any. The nfc modifier is registered by
+// importing github.com/oSethoum/validator/nfc.
+func RegisterModifier(name string, fn func(string) string) {
+	modifiersMu.Lock()
+	defer modifiersMu.Unlock()
+	modifiers[name] = fn
+}
+
+func modifier(name string) (func(string) string, bool) {
+	modifiersMu.RLock()
+	defer modifiersMu.RUnlock()
+	fn, ok := modifiers[name]
+	return fn, ok
+}
+
+// Sanitize applies the modifiers of the mod tag, or of the sanitize tag, to the
+// string fields of the struct s points to, in the order of the tag. Struct runs
+// them before the rules when it is given a pointer. An unknown modifier is
+// returned as an error and leaves the struct unchanged.
+func Sanitize(s any) error {
+	return defaultValidator.Sanitize(s)
+}
+
+func (v *Validator) Sanitize(s any) error {
+	rv := reflect.ValueOf(s)
+	if rv.Kind() != reflect.Pointer || rv.Type().Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validate: sanitize expects a pointer to a struct, got %T", s))
+	}
+	if rv.IsNil() {
+		return nil
+	}
+	return sanitizePlan(v.plan(rv.Type().Elem()), rv.Elem())
+}
+
+// fieldModifiers returns the modifiers of the mod or sanitize tag of a field.
+func fieldModifiers(ft reflect.StructField) []Constraint {
+	tag, ok := ft.Tag.Lookup("mod")
+	if !ok {
+		tag, ok = ft.Tag.Lookup("sanitize")
+	}
+	if !ok || tag == "" {
+		return nil
+	}
+	return parseConstraints(tag)
+}
+
+// hasModifiers reports whether a field of t, or of its embedded structs, has
+// modifiers.
+func hasModifiers(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		ft := t.Field(i)
+		if ft.Anonymous && ft.Type.Kind() == reflect.Struct {
+			if hasModifiers(ft.Type) {
+				return true
+			}
+			continue
+		}
+		if len(fieldModifiers(ft)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func sanitizePlan(p *structPlan, rv reflect.Value) error {
+	fns, err := planModifiers(p)
+	if err != nil {
+		return err
+	}
+	sanitizeFields(p, fns, rv)
+	return nil
+}
+
+// planModifiers resolves the modifiers of the fields of p by field index, before
+// any field is modified.
+func planModifiers(p *structPlan) ([][]func(string) string, error) {
+	fns := make([][]func(string) string, len(p.fields))
+	for i, f := range p.fields {
+		for _, mod := range f.mods {
+			fn, ok := modifier(mod.Kind)
+			if !ok {
+				return nil, fmt.Errorf("validate: struct %s field %s %w", f.structName, f.name, unknownModifier(mod.Kind))
+			}
+			fns[i] = append(fns[i], fn)
+		}
+	}
+	return fns, nil
+}
+
+func sanitizeFields(p *structPlan, fns [][]func(string) string, rv reflect.Value) {
+	for i, f := range p.fields {
+		if len(fns[i]) > 0 {
+			sanitizeValue(rv.FieldByIndex(f.parent).Field(f.index), fns[i])
+		}
+	}
+}
+
+func unknownModifier(name string) error {
+	if name == "nfc" {
+		return fmt.Errorf("unknown modifier %q, import github.com/oSethoum/validator/nfc to register it", name)
+	}
+	return fmt.Errorf("unknown modifier %q", name)
+}
+
+// sanitizeValue modifies strings, pointers to strings and slices of strings.
+func sanitizeValue(fv reflect.Value, fns []func(string) string) {
+	switch fv.Kind() {
+	case reflect.String:
+		s := fv.String()
+		for _, fn := range fns {
+			s = fn(s)
+		}
+		fv.SetString(s)
+	case reflect.Pointer:
+		if !fv.IsNil() {
+			sanitizeValue(fv.Elem(), fns)
+		}
+	case reflect.Slice, reflect.Array:
+		if fv.Type().Elem().Kind() != reflect.String {
+			return
+		}
+		for i := 0; i < fv.Len(); i++ {
+			sanitizeValue(fv.Index(i), fns)
+		}
+	}
+}
+
+func title(s string) string {
+	b := strings.Builder{}
+	start := true
+	for _, r := range s {
+		if start {
+			r = unicode.ToUpper(r)
+		}
+		start = unicode.IsSpace(r) || r == '-'
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func collapseSpaces(s string) string {
+	return spacesRegexp.ReplaceAllString(s, " ")
+}
+
+func stripTags(s string) string {
+	return tagsRegexp.ReplaceAllString(s, "")
+}
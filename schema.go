@@ -0,0 +1,131 @@
+package validator
+
+import (
+	"reflect"
+)
+
+type TypeSchema struct {
+	Name    string
+	PkgPath string
+	Type    reflect.Type
+	Fields  []FieldSchema
+}
+
+type FieldSchema struct {
+	// Name is the Go name of the field and Path its path from the type, the
+	// fields of embedded structs are flattened as Struct does.
+	Name     string
+	Path     string
+	JSONName string
+	// Kind is the kind of the field, pointers are dereferenced.
+	Kind reflect.Kind
+	Type reflect.Type
+	// Constraints have typed params: int64, uint64 or float64 for the numbers,
+	// slices of them or []string for the lists, *regexp.Regexp for match and
+	// the field name for the comparisons.
+	Constraints []Constraint
+	// Struct is the schema of the struct type of the field or of its elements.
+	Struct *TypeSchema
+}
+
+// Schema returns the fields of the struct s and their rules, including the
+// registered ones. It panics on invalid params as Struct does.
+func Schema(s any) *TypeSchema {
+	return defaultValidator.Schema(s)
+}
+
+func (v *Validator) Schema(s any) *TypeSchema {
+	return v.typeSchema(indirect(reflect.TypeOf(s)), map[reflect.Type]*TypeSchema{})
+}
+
+func (v *Validator) typeSchema(t reflect.Type, seen map[reflect.Type]*TypeSchema) *TypeSchema {
+	if schema, ok := seen[t]; ok {
+		return schema
+	}
+	schema := &TypeSchema{Name: t.Name(), PkgPath: t.PkgPath(), Type: t}
+	seen[t] = schema
+	v.fieldSchemas(schema, t, "", v.scopes(nil, t, ""), seen)
+	return schema
+}
+
+func (v *Validator) fieldSchemas(schema *TypeSchema, t reflect.Type, prefix string, scopes []rulesScope, seen map[reflect.Type]*TypeSchema) {
+	for i := 0; i < t.NumField(); i++ {
+		ft := t.Field(i)
+		if ft.Anonymous && ft.Type.Kind() == reflect.Struct {
+			v.fieldSchemas(schema, ft.Type, prefix+ft.Name+".", v.scopes(scopes, ft.Type, ft.Name), seen)
+			continue
+		}
+		if !ft.IsExported() {
+			continue
+		}
+		constraints, _ := v.fieldConstraints(scopes, ft)
+		check := &rulesCheck{structName: t.Name(), name: ft.Name}
+		field := FieldSchema{
+			Name:        ft.Name,
+			Path:        prefix + ft.Name,
+			JSONName:    fieldName(ft),
+			Kind:        indirect(ft.Type).Kind(),
+			Type:        ft.Type,
+			Constraints: []Constraint{},
+		}
+		for _, constraint := range constraints {
+			field.Constraints = append(field.Constraints, typedConstraint(ft.Type, constraint, check))
+		}
+		st := indirect(ft.Type)
+		if st.Kind() == reflect.Slice || st.Kind() == reflect.Array || st.Kind() == reflect.Map {
+			st = indirect(st.Elem())
+		}
+		if st.Kind() == reflect.Struct && st != timeType {
+			field.Struct = v.typeSchema(st, seen)
+		}
+		schema.Fields = append(schema.Fields, field)
+	}
+}
+
+// typedConstraint parses the param of constraint as the rules of a field of type t do.
+func typedConstraint(t reflect.Type, constraint Constraint, check *rulesCheck) Constraint {
+	t = indirect(t)
+	raw := constraint.Param
+	var ok bool
+	switch constraint.Kind {
+	case minLen, maxLen, length:
+		constraint.Param, ok = getIntParam(constraint.Param)
+	case min, max:
+		switch {
+		case isIntKind(t.Kind()):
+			constraint.Param, ok = getIntParam(constraint.Param)
+		case isUintKind(t.Kind()):
+			constraint.Param, ok = getUintParam(constraint.Param)
+		default:
+			constraint.Param, ok = getFloatParam(constraint.Param)
+		}
+	case in, oneOf, out, include, exclude:
+		if t.Kind() == reflect.Slice {
+			t = t.Elem()
+		}
+		switch {
+		case isIntKind(t.Kind()):
+			constraint.Param, ok = getIntListParam(constraint.Param)
+		case isUintKind(t.Kind()):
+			constraint.Param, ok = getUintListParam(constraint.Param)
+		case isFloatKind(t.Kind()):
+			constraint.Param, ok = getFloatListParam(constraint.Param)
+		default:
+			param := getOneOfString(constraint.Param)
+			constraint.Param, ok = param, param != nil
+		}
+	case match:
+		param, _ := getStringParam(constraint.Param)
+		exp, err := compileMatch(param)
+		constraint.Param, ok = exp, err == nil
+	case eqField, neField, gtField, gteField, ltField, lteField:
+		constraint.Param, ok = getStringParam(constraint.Param)
+	default:
+		return constraint
+	}
+	if !ok {
+		constraint.Param = raw
+		check.invalidParam(constraint)
+	}
+	return constraint
+}
@@ -0,0 +1,139 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// Workers sets the number of goroutines validating the items of a slice,
+// GOMAXPROCS by default.
+func Workers(n int) BatchOption {
+	return func(b *batch) {
+		b.workers = n
+	}
+}
+
+// SliceError holds the errors of the invalid items of a slice, ordered by index.
+type SliceError struct {
+	Errors []*RecordError
+}
+
+func (e *SliceError) Error() string {
+	errs := []string{}
+	for _, err := range e.Errors {
+		errs = append(errs, err.Error())
+	}
+	return strings.Join(errs, "\n")
+}
+
+func (e *SliceError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Slice validates the items of a slice of structs, or of pointers to structs,
+// concurrently. The defaults and the modifiers are applied in place, to the
+// elements of items or to the structs they point to. The error is a *SliceError.
+func Slice[T any](items []T, options ...BatchOption) error {
+	return SliceContext(context.Background(), items, options...)
+}
+
+// SliceContext is Slice stopping with the error of ctx once it is done, the
+// error of ctx is only returned when items were left unvalidated.
+func SliceContext[T any](ctx context.Context, items []T, options ...BatchOption) error {
+	b := newBatch(options)
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	validate, err := b.v.itemValidator(indirect(t))
+	if err != nil {
+		return err
+	}
+	workers := b.workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	errs := make([]error, len(items))
+	indexes := make(chan int)
+	wg := sync.WaitGroup{}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				rv := reflect.ValueOf(&items[i]).Elem()
+				if t.Kind() == reflect.Pointer {
+					if rv.IsNil() {
+						continue
+					}
+					rv = rv.Elem()
+				}
+				errs[i] = validate(rv)
+			}
+		}()
+	}
+	var ctxErr error
+feed:
+	for i := range items {
+		select {
+		case <-ctx.Done():
+			ctxErr = ctx.Err()
+			break feed
+		case indexes <- i:
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	if ctxErr != nil {
+		return ctxErr
+	}
+
+	e := &SliceError{}
+	for i, err := range errs {
+		if err != nil {
+			e.Errors = append(e.Errors, &RecordError{Index: i, Err: err})
+		}
+	}
+	if len(e.Errors) > 0 {
+		return e
+	}
+	return nil
+}
+
+// itemValidator returns the defaults, the sanitization and the validation of the
+// addressable values of type t, the plan of t and its modifiers are resolved
+// once for all of them.
+func (v *Validator) itemValidator(t reflect.Type) (func(rv reflect.Value) error, error) {
+	scopes := v.scopes(nil, t, "")
+	plan := v.plan(t)
+	fns, err := planModifiers(plan)
+	if err != nil {
+		return nil, err
+	}
+	if validate, ok := v.generated(t); ok {
+		return func(rv reflect.Value) error {
+			if v.defaults {
+				v.applyDefaults(t, rv, scopes)
+			}
+			sanitizeFields(plan, fns, rv)
+			return validate(rv.Addr().Interface(), v)
+		}, nil
+	}
+	return func(rv reflect.Value) error {
+		if v.defaults {
+			v.applyDefaults(t, rv, scopes)
+		}
+		sanitizeFields(plan, fns, rv)
+		e := &Error{}
+		v.validatePlan(plan, rv, e)
+		if len(e.FieldsErrors) > 0 {
+			return e
+		}
+		return nil
+	}, nil
+}
@@ -0,0 +1,132 @@
+package validator
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrTooManyInvalid is returned when the limit set by StopAfter is reached.
+var ErrTooManyInvalid = errors.New("validate: too many invalid items")
+
+// BatchOption configures the validation of a sequence of items.
+type BatchOption func(*batch)
+
+type batch struct {
+	v         *Validator
+	stopAfter int
+	rejected  io.Writer
+	workers   int
+}
+
+func newBatch(options []BatchOption) *batch {
+	b := &batch{v: defaultValidator}
+	for _, option := range options {
+		option(b)
+	}
+	return b
+}
+
+// WithValidator validates the items with v instead of the default validator.
+func WithValidator(v *Validator) BatchOption {
+	return func(b *batch) {
+		b.v = v
+	}
+}
+
+// StopAfter stops the validation with ErrTooManyInvalid once n items are invalid.
+func StopAfter(n int) BatchOption {
+	return func(b *batch) {
+		b.stopAfter = n
+	}
+}
+
+// RecordError is the error of the item at Index of a stream or of a slice.
+type RecordError struct {
+	Index int
+	Err   error
+}
+
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("validate: record %d: %v", e.Index, e.Err)
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// StreamJSON decodes the records of r one at a time into a struct type T, either
+// newline delimited or the items of a top level array, validates them and calls
+// fn with their index. err is the validation error of the record, or a
+// *RecordError when it could not be decoded. Streaming stops when fn returns an
+// error, which is returned, or on malformed JSON.
+func StreamJSON[T any](r io.Reader, fn func(index int, item T, err error) error, options ...BatchOption) error {
+	b := newBatch(options)
+	reader := bufio.NewReader(r)
+	array, err := isJSONArray(reader)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(reader)
+	if array {
+		if _, err := decoder.Token(); err != nil {
+			return err
+		}
+	}
+
+	invalid := 0
+	for index := 0; ; index++ {
+		if array && !decoder.More() {
+			break
+		}
+		var item T
+		err := decoder.Decode(&item)
+		if err == io.EOF && !array {
+			break
+		}
+		var typeError *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &typeError):
+			err = &RecordError{Index: index, Err: err}
+		case err != nil:
+			return &RecordError{Index: index, Err: err}
+		default:
+			err = b.v.Struct(&item)
+		}
+		if err != nil {
+			invalid++
+		}
+		if err := fn(index, item, err); err != nil {
+			return err
+		}
+		if b.stopAfter > 0 && invalid >= b.stopAfter {
+			return ErrTooManyInvalid
+		}
+	}
+	if array {
+		if _, err := decoder.Token(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isJSONArray reports whether the first value of r is an array.
+func isJSONArray(r *bufio.Reader) (bool, error) {
+	for {
+		c, err := r.ReadByte()
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		switch c {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return c == '[', r.UnreadByte()
+	}
+}
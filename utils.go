@@ -5,7 +5,6 @@ import (
 	"strconv"
 	"strings"
 	"unicode"
-	"unsafe"
 )
 
 func parseConstraints(tag string) []Constraint {
@@ -34,21 +33,15 @@ func parseConstraints(tag string) []Constraint {
 }
 
 func isString(t reflect.Type) bool {
-	return t.String() == typeString || t.String() == typeStringPtr
+	return indirect(t).Kind() == reflect.String
 }
 
 func getStringValue(v reflect.Value) (string, bool) {
-	if v.Type().String() == typeStringPtr {
-		if v.IsNil() {
-			return "", false
-		}
-		return v.Elem().String(), true
-	}
-
-	if v.Type().String() == typeString {
-		return v.String(), true
+	v, ok := elem(v)
+	if !ok || v.Kind() != reflect.String {
+		return "", false
 	}
-	return "", false
+	return v.String(), true
 }
 
 func getStringParam(param any) (string, bool) {
@@ -60,20 +53,15 @@ func getStringParam(param any) (string, bool) {
 }
 
 func isInt(t reflect.Type) bool {
-	return strings.HasPrefix(t.String(), typeInt) || strings.HasPrefix(t.String(), typeIntPtr)
+	return isIntKind(indirect(t).Kind())
 }
 
 func getIntValue(v reflect.Value) (int64, bool) {
-	if strings.HasPrefix(v.Type().String(), typeIntPtr) {
-		if v.IsNil() {
-			return 0, false
-		}
-		return v.Elem().Int(), true
-	}
-	if strings.HasPrefix(v.Type().String(), typeInt) {
-		return v.Int(), true
+	v, ok := elem(v)
+	if !ok || !isIntKind(v.Kind()) {
+		return 0, false
 	}
-	return 0, false
+	return v.Int(), true
 }
 
 func getIntParam(param any) (int64, bool) {
@@ -88,7 +76,7 @@ func getIntParam(param any) (int64, bool) {
 }
 
 func isUint(t reflect.Type) bool {
-	return strings.HasPrefix(t.String(), typeUint) || strings.HasPrefix(t.String(), typeUintPtr)
+	return isUintKind(indirect(t).Kind())
 }
 
 func getUintParam(param any) (uint64, bool) {
@@ -115,34 +103,23 @@ func getOneOfString(param any) []string {
 }
 
 func getUintValue(v reflect.Value) (uint64, bool) {
-	if v.Type().String() == typeIntPtr {
-		if !v.IsNil() {
-			return 0, false
-		}
-		return v.Elem().Uint(), true
-
-	}
-	if v.Type().String() == typeInt {
-		return v.Uint(), true
+	v, ok := elem(v)
+	if !ok || !isUintKind(v.Kind()) {
+		return 0, false
 	}
-	return 0, false
+	return v.Uint(), true
 }
 
 func isFloat(t reflect.Type) bool {
-	return strings.HasPrefix(t.String(), typeFloat) || strings.HasPrefix(t.String(), typeFloatPtr)
+	return isFloatKind(indirect(t).Kind())
 }
 
 func getFloatValue(v reflect.Value) (float64, bool) {
-	if v.Type().String() == typeFloatPtr {
-		if !v.IsNil() {
-			return 0, false
-		}
-		return v.Elem().Float(), true
-	}
-	if v.Type().String() == typeInt {
-		return v.Float(), true
+	v, ok := elem(v)
+	if !ok || !isFloatKind(v.Kind()) {
+		return 0, false
 	}
-	return 0, false
+	return v.Float(), true
 }
 
 func getFloatParam(param any) (float64, bool) {
@@ -221,54 +198,109 @@ func getFloatListParam(param any) ([]float64, bool) {
 }
 
 func isStringArray(t reflect.Type) bool {
-	return strings.HasPrefix(t.String(), typeStringArray)
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.String
 }
 
 func getStringArrayValue(v reflect.Value) ([]string, bool) {
-	if !v.IsNil() {
-		value, ok := v.Interface().([]string)
-		return value, ok
+	if v.IsNil() {
+		return nil, false
 	}
-	return nil, false
+	values := make([]string, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).String()
+	}
+	return values, true
 }
 
 func getIntArrayValue(v reflect.Value) ([]int64, bool) {
-	if !v.IsNil() {
-		i := v.Interface()
-		values := *(*[]int64)(unsafe.Pointer(&i))
-		return values, true
+	if v.IsNil() {
+		return nil, false
 	}
-	return nil, false
+	values := make([]int64, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).Int()
+	}
+	return values, true
 }
 
 func getUintArrayValue(v reflect.Value) ([]uint64, bool) {
-	if !v.IsNil() {
-		i := v.Interface()
-		values := *(*[]uint64)(unsafe.Pointer(&i))
-		return values, true
+	if v.IsNil() {
+		return nil, false
 	}
-	return nil, false
+	values := make([]uint64, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).Uint()
+	}
+	return values, true
 }
 
 func getFloatArrayValue(v reflect.Value) ([]float64, bool) {
-	if !v.IsNil() {
-		i := v.Interface()
-		values := *(*[]float64)(unsafe.Pointer(&i))
-		return values, true
+	if v.IsNil() {
+		return nil, false
 	}
-	return nil, false
+	values := make([]float64, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).Float()
+	}
+	return values, true
 }
 
 func isIntArray(t reflect.Type) bool {
-	return t.String() == typeIntArray
+	return t.Kind() == reflect.Slice && isIntKind(t.Elem().Kind())
 }
 
 func isUintArray(t reflect.Type) bool {
-	return t.String() == typeUintArray
+	return t.Kind() == reflect.Slice && isUintKind(t.Elem().Kind())
 }
 
 func isFloatArray(t reflect.Type) bool {
-	return t.String() == typeFloatArray
+	return t.Kind() == reflect.Slice && isFloatKind(t.Elem().Kind())
+}
+
+func isIntKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUintKind(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
+
+func elem(v reflect.Value) (reflect.Value, bool) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return v, false
+		}
+		return v.Elem(), true
+	}
+	return v, true
+}
+
+func isMissing(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+		return v.IsNil()
+	}
+	return v.IsZero()
+}
+
+func hasConstraint(constraints []Constraint, kind string) bool {
+	for _, constraint := range constraints {
+		if constraint.Kind == kind {
+			return true
+		}
+	}
+	return false
 }
 
 func camel(s string) string {
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"sync"
 )
 
 type Constraint struct {
@@ -16,12 +17,18 @@ type Constraint struct {
 type FieldError struct {
 	Field      string       `json:"field,omitempty"`
 	Value      any          `json:"value,omitempty"`
+	Redacted   bool         `json:"redacted,omitempty"`
 	Struct     string       `json:"struct,omitempty"`
 	Violations []Constraint `json:"violations,omitempty"`
+	// Position locates the field in the config file loaded by LoadConfig.
+	Position *Position `json:"position,omitempty"`
 }
 
 type Error struct {
 	FieldsErrors []FieldError `json:"fieldsErrors,omitempty"`
+	// Truncated reports that the validation stopped at the MaxErrors cap, the
+	// fields after the last error were not checked.
+	Truncated bool `json:"truncated,omitempty"`
 }
 
 func (e *Error) Error() string {
@@ -31,467 +38,202 @@ func (e *Error) Error() string {
 		for _, v := range err.Violations {
 			vErrs = append(vErrs, fmt.Sprintf("kind: %s, param: %+v", v.Kind, v.Param))
 		}
-		errs = append(errs, fmt.Sprintf("\nfield: %s \nstruct: %s \nvalue: %+v \nviolations: %s\n", err.Field, err.Struct, err.Value, strings.Join(vErrs, " | ")))
+		var value any = err.Value
+		if err.Redacted && err.Value == nil {
+			value = redactedValue
+		}
+		location := ""
+		if err.Position != nil {
+			location = fmt.Sprintf("\nposition: %s", err.Position)
+		}
+		errs = append(errs, fmt.Sprintf("\nfield: %s \nstruct: %s \nvalue: %+v \nviolations: %s%s\n", err.Field, err.Struct, value, strings.Join(vErrs, " | "), location))
+	}
+	if e.Truncated {
+		errs = append(errs, "\n(truncated)\n")
 	}
 	return strings.Join(errs, "")
 }
 
+type Validator struct {
+	valuePolicy    ValuePolicy
+	hashKey        []byte
+	sensitiveNames []*regexp.Regexp
+	maxErrors      int
+	bail           bool
+	maxBodySize    int64
+	strictJSON     bool
+	defaults       bool
+	mu             sync.RWMutex
+	types          map[reflect.Type]*typeRules
+}
+
+type Option func(*Validator)
+
+func New(options ...Option) *Validator {
+	v := &Validator{
+		valuePolicy:    IncludeNonSensitive,
+		sensitiveNames: defaultSensitiveNames,
+		maxBodySize:    defaultMaxBodySize,
+		types:          map[reflect.Type]*typeRules{},
+	}
+	for _, option := range options {
+		option(v)
+	}
+	if v.valuePolicy == HashSensitive && v.hashKey == nil {
+		v.hashKey = randomKey()
+	}
+	return v
+}
+
+var defaultValidator = New()
+
+// SetDefault replaces the validator used by the package level functions.
+func SetDefault(v *Validator) {
+	defaultValidator = v
+}
+
 func Struct(s any) error {
+	return defaultValidator.Struct(s)
+}
+
+func (v *Validator) Struct(s any) error {
 	t := reflect.TypeOf(s)
-	v := reflect.ValueOf(s)
+	rv := reflect.ValueOf(s)
 	if t.Kind() == reflect.Pointer {
 		t = t.Elem()
-		v = v.Elem()
+		rv = rv.Elem()
 	}
-	fieldsErrors := []FieldError{}
-	for i := 0; i < t.NumField(); i++ {
-		ft := t.Field(i)
-		fv := v.Field(i)
-
-		if ft.Anonymous && ft.Type.Kind() == reflect.Struct {
-			err := Struct(fv.Interface())
-			if err != nil {
-				e := err.(*Error)
-				fieldsErrors = append(fieldsErrors, e.FieldsErrors...)
+	if v.defaults && rv.CanAddr() {
+		v.applyDefaults(t, rv, v.scopes(nil, t, ""))
+	}
+	if validate, ok := v.generated(t); ok {
+		if rv.CanAddr() && hasModifiers(t) {
+			if err := sanitizePlan(v.plan(t), rv); err != nil {
+				return err
 			}
 		}
-
-		tag, ok := ft.Tag.Lookup("validate")
-		if !ok {
-			continue
-		}
-
-		constraints := parseConstraints(tag)
-		fieldError := FieldError{
-			Field:  ft.Name,
-			Struct: t.Name(),
-		}
-		if tag, ok := ft.Tag.Lookup("json"); ok {
-			fieldError.Field = strings.Split(tag, ",")[0]
-		} else {
-			fieldError.Field = ft.Name
+		if !rv.CanAddr() {
+			p := reflect.New(t)
+			p.Elem().Set(rv)
+			rv = p.Elem()
 		}
-		violations := []Constraint{}
-
-		if len(constraints) == 0 {
-			continue
+		return validate(rv.Addr().Interface(), v)
+	}
+	plan := v.plan(t)
+	if rv.CanAddr() {
+		if err := sanitizePlan(plan, rv); err != nil {
+			return err
 		}
+	}
+	e := &Error{}
+	v.validatePlan(plan, rv, e)
+	if len(e.FieldsErrors) > 0 {
+		return e
+	}
+	return nil
+}
 
-		if isString(ft.Type) {
-			value, ok := getStringValue(v.Field(i))
-			if !ok {
-				if strings.Contains(tag, required) {
-					violations = append(violations, Constraint{
-						Tag:  required,
-						Kind: required,
-					})
-				}
-			} else {
-				fieldError.Value = value
-				for _, constraint := range constraints {
-					if constraint.Param != nil {
-						switch constraint.Kind {
-						case minLen:
-							param, ok := getIntParam(constraint.Param)
-							if !ok {
-								panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-							}
-							constraint.Param = param
-							if int64(len(value)) < param {
-								violations = append(violations, constraint)
-							}
-						case maxLen:
-							param, ok := getIntParam(constraint.Param)
-							if !ok {
-								panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-							}
-							constraint.Param = param
-							if int64(len(value)) > param {
-								violations = append(violations, constraint)
-							}
-						case length:
-							param, ok := getIntParam(constraint.Param)
-							if !ok {
-								panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-							}
-							constraint.Param = param
-							if int64(len(value)) != param {
-								violations = append(violations, constraint)
-							}
-
-						case in:
-							param := getOneOfString(constraint.Param)
-							if param == nil {
-								panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-							}
-							constraint.Param = param
-							if !inArray(param, value) {
-								violations = append(violations, constraint)
-							}
-						case out:
-							param := getOneOfString(constraint.Param)
-							if param == nil {
-								panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-							}
-							constraint.Param = param
-							if inArray(param, value) {
-								violations = append(violations, constraint)
-							}
-						}
-					} else {
-						value, _ := getStringValue(v.Field(i))
-						if exp, ok := regexMap[constraint.Kind]; ok {
-							if !exp.MatchString(value) {
-								violations = append(violations, constraint)
-							}
-						} else {
-							param, ok := getStringParam(constraint.Param)
-							if ok {
-								panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-							}
-							switch constraint.Kind {
-							case match:
-								if !regexp.MustCompile(param).MatchString(value) {
-									violations = append(violations, constraint)
-								}
-							}
-						}
-					}
+// structPlan lists the validated fields of a struct type with their rules, it is
+// built once per call and shared by the values validated with it.
+type structPlan struct {
+	fields []fieldPlan
+}
 
-				}
-			}
-		}
+type fieldPlan struct {
+	// parent is the index of the embedded struct holding the field, if any.
+	parent      []int
+	index       int
+	structName  string
+	name        string
+	field       string
+	constraints []Constraint
+	// mods are the modifiers of the mod tag, applied by Sanitize.
+	mods []Constraint
+}
 
-		if isInt(ft.Type) {
-			value, ok := getIntValue(v.Field(i))
-			if !ok {
-				if strings.Contains(tag, required) {
-					violations = append(violations, Constraint{
-						Tag:  required,
-						Kind: required,
-					})
-				}
-			} else {
-				for _, constraint := range constraints {
-					switch constraint.Kind {
-					case min:
-						param, ok := getIntParam(constraint.Param)
-						if !ok {
-							panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-						}
-						constraint.Param = param
-						if value <= param {
-							violations = append(violations, constraint)
-						}
-					case max:
-						param, ok := getIntParam(constraint.Param)
-						if !ok {
-							panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-						}
-						constraint.Param = param
-						if value > param {
-							violations = append(violations, constraint)
-						}
-					}
-				}
-			}
-			fieldError.Value = value
-		}
+func (v *Validator) plan(t reflect.Type) *structPlan {
+	p := &structPlan{}
+	v.planFields(p, t, nil, v.scopes(nil, t, ""))
+	return p
+}
 
-		if isUint(ft.Type) {
-			value, ok := getUintValue(v.Field(i))
-			if !ok {
-				if strings.Contains(tag, required) {
-					violations = append(violations, Constraint{
-						Tag:  required,
-						Kind: required,
-					})
-				}
-			} else {
-				for _, constraint := range constraints {
-					switch constraint.Kind {
-					case min:
-						param, ok := getUintParam(constraint.Param)
-						if !ok {
-							panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-						}
-						constraint.Param = param
-						if value <= param {
-							violations = append(violations, constraint)
-						}
-					case max:
-						param, ok := getUintParam(constraint.Param)
-						if !ok {
-							panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-						}
-						constraint.Param = param
-						if value > param {
-							violations = append(violations, constraint)
-						}
-					}
-				}
-			}
-			fieldError.Value = value
-		}
+func (v *Validator) planFields(p *structPlan, t reflect.Type, parent []int, scopes []rulesScope) {
+	for i := 0; i < t.NumField(); i++ {
+		ft := t.Field(i)
 
-		if isFloat(ft.Type) {
-			value, ok := getFloatValue(v.Field(i))
-			if !ok {
-				if strings.Contains(tag, required) {
-					violations = append(violations, Constraint{
-						Tag:  required,
-						Kind: required,
-					})
-				}
-			} else {
-				for _, constraint := range constraints {
-					switch constraint.Kind {
-					case min:
-						param, ok := getFloatParam(constraint.Param)
-						if !ok {
-							panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-						}
-						constraint.Param = param
-						if value <= param {
-							violations = append(violations, constraint)
-						}
-					case max:
-						param, ok := getFloatParam(constraint.Param)
-						if !ok {
-							panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-						}
-						constraint.Param = param
-						if value > param {
-							violations = append(violations, constraint)
-						}
-					}
-				}
-			}
-			fieldError.Value = value
+		if ft.Anonymous && ft.Type.Kind() == reflect.Struct {
+			v.planFields(p, ft.Type, append(parent[:len(parent):len(parent)], i), v.scopes(scopes, ft.Type, ft.Name))
+			continue
 		}
 
-		if isStringArray(ft.Type) {
-			value, ok := getStringArrayValue(v.Field(i))
-			if !ok {
-				if strings.Contains(tag, required) {
-					violations = append(violations, Constraint{
-						Tag:  required,
-						Kind: required,
-					})
-				}
-			} else {
-				for _, constraint := range constraints {
-					switch constraint.Kind {
-					case in:
-						param, ok := getStringListParam(constraint.Param)
-						if !ok {
-							panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-						}
-						constraint.Param = param
-						if !insArray(param, value) {
-							constraints = append(constraints, constraint)
-						}
-					case out:
-						param, ok := getStringListParam(constraint.Param)
-						if !ok {
-							panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-						}
-						constraint.Param = param
-						if !outsArray(param, value) {
-							constraints = append(constraints, constraint)
-						}
-					case include:
-						param, ok := getStringListParam(constraint.Param)
-						if !ok {
-							panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-						}
-						constraint.Param = param
-						if !insArray(value, param) {
-							constraints = append(constraints, constraint)
-						}
-					case exclude:
-						param, ok := getStringListParam(constraint.Param)
-						if !ok {
-							panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-						}
-						constraint.Param = param
-						if !outsArray(value, param) {
-							constraints = append(constraints, constraint)
-						}
-					}
-				}
-			}
+		constraints, _ := v.fieldConstraints(scopes, ft)
+		mods := fieldModifiers(ft)
+		if len(constraints) == 0 && len(mods) == 0 {
+			continue
 		}
+		p.fields = append(p.fields, fieldPlan{
+			parent:      parent,
+			index:       i,
+			structName:  t.Name(),
+			name:        ft.Name,
+			field:       fieldName(ft),
+			constraints: constraints,
+			mods:        mods,
+		})
+	}
+}
 
-		if isIntArray(ft.Type) {
-			value, ok := getIntArrayValue(v.Field(i))
-			if !ok {
-				if strings.Contains(tag, required) {
-					violations = append(violations, Constraint{
-						Tag:  required,
-						Kind: required,
-					})
-				}
-			} else {
-				for _, constraint := range constraints {
-					switch constraint.Kind {
-					case in:
-						param, ok := getIntListParam(constraint.Param)
-						if !ok {
-							panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-						}
-						constraint.Param = param
-						if !insArray(param, value) {
-							constraints = append(constraints, constraint)
-						}
-					case out:
-						param, ok := getIntListParam(constraint.Param)
-						if !ok {
-							panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-						}
-						constraint.Param = param
-						if !outsArray(param, value) {
-							constraints = append(constraints, constraint)
-						}
-					case include:
-						param, ok := getIntListParam(constraint.Param)
-						if !ok {
-							panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-						}
-						constraint.Param = param
-						if !insArray(value, param) {
-							constraints = append(constraints, constraint)
-						}
-					case exclude:
-						param, ok := getIntListParam(constraint.Param)
-						if !ok {
-							panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-						}
-						constraint.Param = param
-						if !outsArray(value, param) {
-							constraints = append(constraints, constraint)
-						}
-					}
-				}
-			}
+func (v *Validator) validatePlan(p *structPlan, rv reflect.Value, e *Error) {
+	for _, f := range p.fields {
+		if e.Truncated {
+			return
 		}
-
-		if isUintArray(ft.Type) {
-			value, ok := getUintArrayValue(v.Field(i))
-			if !ok {
-				if strings.Contains(tag, required) {
-					violations = append(violations, Constraint{
-						Tag:  required,
-						Kind: required,
-					})
-				}
-			} else {
-				for _, constraint := range constraints {
-					switch constraint.Kind {
-					case in:
-						param, ok := getUintListParam(constraint.Param)
-						if !ok {
-							panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-						}
-						constraint.Param = param
-						if !insArray(param, value) {
-							constraints = append(constraints, constraint)
-						}
-					case out:
-						param, ok := getUintListParam(constraint.Param)
-						if !ok {
-							panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-						}
-						constraint.Param = param
-						if !outsArray(param, value) {
-							constraints = append(constraints, constraint)
-						}
-					case include:
-						param, ok := getUintListParam(constraint.Param)
-						if !ok {
-							panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-						}
-						constraint.Param = param
-						if !insArray(value, param) {
-							constraints = append(constraints, constraint)
-						}
-					case exclude:
-						param, ok := getUintListParam(constraint.Param)
-						if !ok {
-							panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-						}
-						constraint.Param = param
-						if !outsArray(value, param) {
-							constraints = append(constraints, constraint)
-						}
-					}
-				}
-			}
+		if len(f.constraints) == 0 {
+			continue
 		}
+		parent := rv.FieldByIndex(f.parent)
+		v.checkField(e, f.structName, f.name, f.field, f.constraints, parent.Field(f.index), func(name string) (reflect.Value, bool) {
+			field := parent.FieldByName(name)
+			return field, field.IsValid()
+		})
+	}
+}
 
-		if isFloatArray(ft.Type) {
-			value, ok := getFloatArrayValue(v.Field(i))
-			if !ok {
-				if strings.Contains(tag, required) {
-					violations = append(violations, Constraint{
-						Tag:  required,
-						Kind: required,
-					})
-				}
-			} else {
-				for _, constraint := range constraints {
-					switch constraint.Kind {
-					case in:
-						param, ok := getFloatListParam(constraint.Param)
-						if !ok {
-							panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-						}
-						constraint.Param = param
-						if !insArray(param, value) {
-							constraints = append(constraints, constraint)
-						}
-					case out:
-						param, ok := getFloatListParam(constraint.Param)
-						if !ok {
-							panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-						}
-						constraint.Param = param
-						if !outsArray(param, value) {
-							constraints = append(constraints, constraint)
-						}
-					case include:
-						param, ok := getFloatListParam(constraint.Param)
-						if !ok {
-							panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-						}
-						constraint.Param = param
-						if !insArray(value, param) {
-							constraints = append(constraints, constraint)
-						}
-					case exclude:
-						param, ok := getFloatListParam(constraint.Param)
-						if !ok {
-							panic(fmt.Sprintf("validate: struct %s field %s tag %s invalid param %v", t.Name(), ft.Name, constraint.Tag, constraint.Param))
-						}
-						constraint.Param = param
-						if !outsArray(value, param) {
-							constraints = append(constraints, constraint)
-						}
-					}
-				}
-			}
-		}
+// checkField appends the error of a struct field to e, if any.
+func (v *Validator) checkField(e *Error, structName, name, field string, constraints []Constraint, fv reflect.Value, lookup func(string) (reflect.Value, bool)) {
+	check := &rulesCheck{
+		structName: structName,
+		name:       name,
+		bail:       v.bail || hasConstraint(constraints, bail),
+		field:      lookup,
+	}
+	fieldError := FieldError{
+		Field:  field,
+		Struct: structName,
+		Value:  check.value(fv, constraints),
+	}
+	if len(check.violations) == 0 {
+		return
+	}
+	fieldError.Violations = check.violations
+	v.appendFieldError(e, fieldError, name, constraints)
+}
 
-		if len(violations) > 0 {
-			fieldError.Violations = violations
-			fieldsErrors = append(fieldsErrors, fieldError)
-		}
+// appendFieldError appends fieldError to e and flags e as Truncated once the
+// maximum number of errors is reached, so that the validation stops.
+func (v *Validator) appendFieldError(e *Error, fieldError FieldError, name string, constraints []Constraint) {
+	v.redact(&fieldError, name, constraints)
+	e.FieldsErrors = append(e.FieldsErrors, fieldError)
+	if v.maxErrors > 0 && len(e.FieldsErrors) >= v.maxErrors {
+		e.Truncated = true
 	}
+}
 
-	if len(fieldsErrors) > 0 {
-		return &Error{
-			FieldsErrors: fieldsErrors,
+func fieldName(ft reflect.StructField) string {
+	if tag, ok := ft.Tag.Lookup("json"); ok {
+		if name := strings.Split(tag, ",")[0]; name != "" {
+			return name
 		}
 	}
-	return nil
+	return ft.Name
 }
@@ -1,10 +1,22 @@
 package validator_test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/oSethoum/validator"
+	"github.com/oSethoum/validator/rules"
 )
 
 type BaseModel struct {
@@ -42,3 +54,985 @@ func TestStruct(T *testing.T) {
 		T.Error(err)
 	}
 }
+
+type Credentials struct {
+	Login    string `json:"login" validate:"minLen=5"`
+	Password string `json:"password" validate:"minLen=8"`
+	Pin      string `json:"pin" validate:"len=4;sensitive"`
+}
+
+func TestRedaction(T *testing.T) {
+	credentials := Credentials{Login: "joe", Password: "hunter2", Pin: "123"}
+
+	err := validator.Struct(credentials).(*validator.Error)
+	if len(err.FieldsErrors) != 3 {
+		T.Fatalf("expected 3 field errors, got %d", len(err.FieldsErrors))
+	}
+	if err.FieldsErrors[0].Value != "joe" || err.FieldsErrors[0].Redacted {
+		T.Errorf("expected login value to be kept, got %+v", err.FieldsErrors[0])
+	}
+	for _, fe := range err.FieldsErrors[1:] {
+		if fe.Value != nil || !fe.Redacted {
+			T.Errorf("expected %s value to be redacted, got %+v", fe.Field, fe.Value)
+		}
+	}
+	if strings.Contains(err.Error(), "hunter2") {
+		T.Error("error message leaks the password")
+	}
+
+	err = validator.New(validator.WithValuePolicy(validator.IncludeNone)).Struct(credentials).(*validator.Error)
+	if err.FieldsErrors[0].Value != nil {
+		T.Errorf("expected no values, got %+v", err.FieldsErrors[0].Value)
+	}
+
+	err = validator.New(validator.WithValuePolicy(validator.HashSensitive)).Struct(credentials).(*validator.Error)
+	if value, _ := err.FieldsErrors[1].Value.(string); !strings.HasPrefix(value, "hmac-sha256:") {
+		T.Errorf("expected hashed password, got %+v", err.FieldsErrors[1].Value)
+	}
+	digest := func(key string) any {
+		v := validator.New(validator.WithValuePolicy(validator.HashSensitive), validator.HashKey([]byte(key)))
+		return v.Struct(credentials).(*validator.Error).FieldsErrors[1].Value
+	}
+	if digest("k1") != digest("k1") || digest("k1") == digest("k2") {
+		T.Errorf("expected the digests to depend on the key only, got %v and %v", digest("k1"), digest("k2"))
+	}
+	if digest("k1") == err.FieldsErrors[1].Value {
+		T.Error("expected a random key without HashKey")
+	}
+}
+
+type Signup struct {
+	Name  string `json:"name" validate:"minLen=5;alpha"`
+	Email string `json:"email" validate:"bail;email;minLen=10"`
+	Age   int    `json:"age" validate:"min=18;max=130"`
+}
+
+func TestStopOnFirstError(T *testing.T) {
+	signup := Signup{Name: "jo3", Email: "nope", Age: 10}
+
+	err := validator.Struct(signup).(*validator.Error)
+	if len(err.FieldsErrors) != 3 || err.Truncated {
+		T.Fatalf("expected 3 field errors, got %+v", err)
+	}
+	if len(err.FieldsErrors[0].Violations) != 2 {
+		T.Errorf("expected every name rule to be evaluated, got %+v", err.FieldsErrors[0].Violations)
+	}
+	if len(err.FieldsErrors[1].Violations) != 1 {
+		T.Errorf("expected email to bail after the first violation, got %+v", err.FieldsErrors[1].Violations)
+	}
+
+	err = validator.New(validator.StopOnFirstError()).Struct(signup).(*validator.Error)
+	if len(err.FieldsErrors) != 1 || len(err.FieldsErrors[0].Violations) != 1 || !err.Truncated {
+		T.Errorf("expected a single truncated violation, got %+v", err)
+	}
+
+	err = validator.New(validator.MaxErrors(2)).Struct(signup).(*validator.Error)
+	if len(err.FieldsErrors) != 2 || !err.Truncated {
+		T.Errorf("expected 2 truncated field errors, got %+v", err)
+	}
+
+	// the fields past the cap are not checked, the invalid param of Age would panic
+	capped := struct {
+		Name string `validate:"minLen=5"`
+		Age  int    `validate:"min=x"`
+	}{Name: "jo"}
+	err = validator.New(validator.StopOnFirstError()).Struct(capped).(*validator.Error)
+	if len(err.FieldsErrors) != 1 || !err.Truncated {
+		T.Errorf("expected a single truncated field error, got %+v", err)
+	}
+}
+
+type Order struct {
+	Reference string   `json:"reference" validate:"required"`
+	Quantity  int      `json:"quantity" validate:"required;min=1;max=10"`
+	Stock     uint     `json:"stock" validate:"min=5"`
+	Discount  float64  `json:"discount" validate:"max=0.5"`
+	Tags      []string `json:"tags" validate:"in=new,sale;include=new"`
+	Codes     []int    `json:"codes" validate:"out=0;exclude=13"`
+}
+
+func TestRules(T *testing.T) {
+	valid := Order{Reference: "A-1", Quantity: 1, Stock: 5, Discount: 0.5, Tags: []string{"new"}, Codes: []int{1}}
+	if err := validator.Struct(valid); err != nil {
+		T.Errorf("expected min and max to be inclusive, got %v", err)
+	}
+	valid.Quantity = 10
+	if err := validator.Struct(valid); err != nil {
+		T.Errorf("expected max to be inclusive, got %v", err)
+	}
+
+	err := validator.Struct(Order{Stock: 4, Discount: 0.6, Tags: []string{"old"}, Codes: []int{0, 13}})
+	var e *validator.Error
+	if !errors.As(err, &e) {
+		T.Fatalf("expected errors, got %v", err)
+	}
+	expected := map[string][]string{
+		"reference": {"required"},
+		"quantity":  {"required"},
+		"stock":     {"min"},
+		"discount":  {"max"},
+		"tags":      {"in", "include"},
+		"codes":     {"out", "exclude"},
+	}
+	for field, kinds := range expected {
+		fieldError := e.Field(field)
+		if fieldError == nil || len(fieldError.Violations) != len(kinds) {
+			T.Errorf("expected %v violations on %s, got %+v", kinds, field, fieldError)
+			continue
+		}
+		for i, kind := range kinds {
+			if fieldError.Violations[i].Kind != kind {
+				T.Errorf("expected %s violation on %s, got %+v", kind, field, fieldError.Violations)
+			}
+		}
+	}
+}
+
+func TestErrorsIs(T *testing.T) {
+	err := validator.Struct(Signup{Name: "j0", Email: "nope@example.com", Age: 10})
+
+	if !errors.Is(err, validator.ErrMinLen) || !errors.Is(err, validator.ErrMin) {
+		T.Errorf("expected minLen and min sentinels, got %v", err)
+	}
+	if errors.Is(err, validator.ErrEmail) {
+		T.Errorf("unexpected email sentinel in %v", err)
+	}
+
+	var fieldError validator.FieldError
+	if !errors.As(err, &fieldError) || fieldError.Field != "name" {
+		T.Errorf("expected the name field error, got %+v", fieldError)
+	}
+
+	e := err.(*validator.Error)
+	if e.Field("age") == nil || e.Field("email") != nil {
+		T.Errorf("unexpected fields in %+v", e.ByPath())
+	}
+	if !e.HasViolation("name", "alpha") || e.HasViolation("age", "max") {
+		T.Errorf("unexpected violations in %+v", e.ByPath())
+	}
+	if len(e.ByPath()["name"]) != 2 {
+		T.Errorf("expected two name violations, got %+v", e.ByPath())
+	}
+}
+
+type Period struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end" validate:"gtField=Start"`
+}
+
+func TestVar(T *testing.T) {
+	if err := validator.Var("john@example.com", "email;maxLen=254"); err != nil {
+		T.Error(err)
+	}
+	if err := validator.String("j0hn", "alpha"); !errors.Is(err, validator.ErrAlpha) {
+		T.Errorf("expected alpha violation, got %v", err)
+	}
+	var fieldError validator.FieldError
+	if err := validator.Int(17, "min=18"); !errors.As(err, &fieldError) || fieldError.Value != int64(17) {
+		T.Errorf("expected min violation, got %v", err)
+	}
+	if err := validator.Float(0.5, "min=0;max=1"); err != nil {
+		T.Error(err)
+	}
+	if err := validator.Var([]string{"admin", "root"}, "in=admin,user"); !errors.Is(err, validator.ErrIn) {
+		T.Errorf("expected in violation, got %v", err)
+	}
+	if err := validator.Var(nil, "required"); !errors.Is(err, validator.ErrRequired) {
+		T.Errorf("expected required violation, got %v", err)
+	}
+	if err := validator.VarWithValue("secret", "secret", "eqField"); err != nil {
+		T.Error(err)
+	}
+	if err := validator.VarWithValue(3, 5, "gtField"); !errors.Is(err, validator.ErrGtField) {
+		T.Errorf("expected gtField violation, got %v", err)
+	}
+
+	now := time.Now()
+	if err := validator.Struct(Period{Start: now, End: now.Add(-time.Hour)}); !errors.Is(err, validator.ErrGtField) {
+		T.Errorf("expected gtField violation, got %v", err)
+	}
+}
+
+type Address struct {
+	Line1   string
+	Country string `json:"country" validate:"len=2"`
+}
+
+func TestRulesBuilder(T *testing.T) {
+	v := validator.New()
+	validator.ForValidator[Address](v).
+		Field(func(a *Address) *string { return &a.Line1 }, rules.Required(), rules.MaxLen(10)).
+		Field(func(a *Address) *string { return &a.Country }, rules.In("DZ", "FR"))
+
+	err := v.Struct(Address{Country: "USA"}).(*validator.Error)
+	if !err.HasViolation("Line1", "required") {
+		T.Errorf("expected required Line1, got %+v", err.ByPath())
+	}
+	if !err.HasViolation("country", "in") || err.HasViolation("country", "len") {
+		T.Errorf("expected the builder rules to replace the tag, got %+v", err.ByPath())
+	}
+
+	validator.ForValidator[Address](v).MergeTags()
+	err = v.Struct(Address{Line1: "1 rue", Country: "USA"}).(*validator.Error)
+	if !err.HasViolation("country", "in") || !err.HasViolation("country", "len") {
+		T.Errorf("expected the builder rules to merge with the tag, got %+v", err.ByPath())
+	}
+
+	validator.ForValidator[User](v).Field(func(u *User) *string { return &u.ID }, rules.Len(3))
+	if err := v.Struct(User{BaseModel: BaseModel{ID: "abc"}, Name: "Oussama"}); err != nil {
+		T.Errorf("expected the embedded ID rules to be replaced, got %v", err)
+	}
+}
+
+func TestRegisterStructRules(T *testing.T) {
+	v := validator.New()
+	v.RegisterStructRules(&Address{}, map[string]string{
+		"Line1":   "required;maxLen=10",
+		"Country": "in=DZ,FR",
+	})
+	v.RegisterStructRules(User{}, map[string]string{"BaseModel.ID": "len=3"})
+
+	err := v.Struct(Address{Line1: "1 rue de la paix", Country: "DZ"}).(*validator.Error)
+	if !err.HasViolation("Line1", "maxLen") || err.Field("country") != nil {
+		T.Errorf("unexpected violations %+v", err.ByPath())
+	}
+	if err := v.Struct(User{BaseModel: BaseModel{ID: "abc"}, Name: "Oussama"}); err != nil {
+		T.Errorf("expected the embedded ID rules to be replaced, got %v", err)
+	}
+}
+
+func TestJSONSchema(T *testing.T) {
+	schema := validator.JSONSchema(User{})
+	if schema["$schema"] != "https://json-schema.org/draft/2020-12/schema" || schema["title"] != "User" {
+		T.Errorf("unexpected schema header %+v", schema)
+	}
+
+	properties := schema["properties"].(map[string]any)
+	name := properties["name"].(map[string]any)
+	if name["type"] != "string" || name["minLength"] != int64(5) || name["pattern"] != "^[a-zA-Z]+$" {
+		T.Errorf("unexpected name schema %+v", name)
+	}
+	if id := properties["id"].(map[string]any); id["minLength"] != int64(10) {
+		T.Errorf("expected the embedded id property, got %+v", properties)
+	}
+	if role := properties["role"].(map[string]any); role["$ref"] != "#/$defs/Role" {
+		T.Errorf("expected a role reference, got %+v", role)
+	}
+
+	defs := schema["$defs"].(map[string]any)
+	users := defs["Role"].(map[string]any)["properties"].(map[string]any)["users"].(map[string]any)
+	if users["items"].(map[string]any)["$ref"] != "#" {
+		T.Errorf("expected users to reference the root schema, got %+v", users)
+	}
+
+	signup := validator.JSONSchema(Signup{})["properties"].(map[string]any)
+	if email := signup["email"].(map[string]any); email["format"] != "email" {
+		T.Errorf("unexpected email schema %+v", email)
+	}
+	if age := signup["age"].(map[string]any); age["minimum"] != int64(18) || age["maximum"] != int64(130) {
+		T.Errorf("unexpected age schema %+v", age)
+	}
+
+	jar := validator.JSONSchema(Jar{})
+	properties = jar["properties"].(map[string]any)
+	if properties["local"].(map[string]any)["$ref"] != "#/$defs/Cookie" || properties["remote"].(map[string]any)["$ref"] != "#/$defs/http.Cookie" {
+		T.Errorf("expected the cookies to be defined apart, got %+v", properties)
+	}
+	if _, ok := jar["$defs"].(map[string]any)["http.Cookie"].(map[string]any)["properties"].(map[string]any)["Expires"]; !ok {
+		T.Errorf("expected the http.Cookie definition, got %+v", jar["$defs"])
+	}
+}
+
+// Cookie is named like http.Cookie.
+type Cookie struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type Jar struct {
+	Local  Cookie      `json:"local"`
+	Remote http.Cookie `json:"remote"`
+}
+
+type Job struct {
+	Name     string    `json:"name" validate:"required;alphaNumeric"`
+	Schedule string    `json:"schedule" validate:"cron"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end" validate:"gtField=Start"`
+}
+
+func TestOpenAPI(T *testing.T) {
+	openapi := validator.NewOpenAPI(Job{}, Signup{}).Info("Jobs", "1.2.0")
+
+	schemas := openapi.Components()["schemas"].(map[string]any)
+	job := schemas["Job"].(map[string]any)["properties"].(map[string]any)
+	if rules := job["schedule"].(map[string]any)["x-validator-rule"]; !reflect.DeepEqual(rules, []string{"cron"}) {
+		T.Errorf("expected a cron extension, got %+v", job["schedule"])
+	}
+	if rules := job["end"].(map[string]any)["x-validator-rule"]; !reflect.DeepEqual(rules, []string{"gtField=Start"}) {
+		T.Errorf("expected a gtField extension, got %+v", job["end"])
+	}
+	code := validator.NewOpenAPI(Invite{}).Components()["schemas"].(map[string]any)["Invite"].(map[string]any)["properties"].(map[string]any)["code"].(map[string]any)
+	if _, ok := code["pattern"]; ok || !reflect.DeepEqual(code["x-validator-rule"], []string{`match=(?i)^[a-z]{3}-(?P<n>\d+)\z`}) {
+		T.Errorf("expected the flagged pattern as an extension, got %+v", code)
+	}
+
+	data, _ := openapi.YAML()
+	for _, line := range []string{
+		"openapi: \"3.1.0\"\n",
+		"info:\n  title: Jobs\n  version: \"1.2.0\"\n",
+		"    Job:\n",
+		"          format: date-time\n",
+		"          minimum: 18\n",
+		"        - name\n",
+		"            - \"gtField=Start\"\n",
+	} {
+		if !strings.Contains(string(data), line) {
+			T.Errorf("expected %q in\n%s", line, data)
+		}
+	}
+
+	if _, err := openapi.JSON(); err != nil {
+		T.Error(err)
+	}
+	if data, _ := validator.NewOpenAPI(Job{}).JSON(); !strings.Contains(string(data), `"title": "API"`) {
+		T.Errorf("expected a default info object in\n%s", data)
+	}
+}
+
+type Invite struct {
+	Code  string   `json:"code" validate:"match=(?i)^[a-z]{3}-(?P<n>\\d+)\\z"`
+	Email string   `json:"email" validate:"email"`
+	Roles []string `json:"roles,omitempty" validate:"in=admin,user"`
+}
+
+type Member struct {
+	Role string `json:"role" validate:"in=admin,user;maxLen=5"`
+	Age  int    `json:"age" validate:"required;min=18"`
+}
+
+func TestZod(T *testing.T) {
+	ts := validator.Zod(Invite{}, Job{}, Member{})
+	for _, fragment := range []string{
+		"import { z } from \"zod\";",
+		"export interface Invite {",
+		"  roles?: string[] | null;",
+		`code: z.string().regex(new RegExp("^[a-z]{3}-(?<n>\\d+)$", "i")),`,
+		`[\\u00A0-\\uD7FF`,
+		`.refine((v) => v.every((e) => ["admin","user"].includes(e)), { message: "in" })`,
+		"export const JobSchema: z.ZodType<Job> = z.lazy(() =>",
+		`.refine((o) => o.end == null || o.start == null || o.end > o.start, { path: ["end"], message: "gtField=Start" })`,
+		`role: z.string().refine((v) => ["admin","user"].includes(v), { message: "in" }).refine((v) => new TextEncoder().encode(v).length <= 5, { message: "maxLen" }),`,
+		`age: z.number().int().gte(18).refine((v) => v !== 0, { message: "required" }),`,
+	} {
+		if !strings.Contains(ts, fragment) {
+			T.Errorf("expected %s in\n%s", fragment, ts)
+		}
+	}
+
+	ts = validator.Zod(Jar{})
+	for _, fragment := range []string{
+		"export interface Cookie {",
+		"export interface http_Cookie {",
+		"  remote: http_Cookie;",
+		"export const http_CookieSchema: z.ZodType<http_Cookie>",
+	} {
+		if !strings.Contains(ts, fragment) {
+			T.Errorf("expected %s in\n%s", fragment, ts)
+		}
+	}
+}
+
+func TestMessage(T *testing.T) {
+	messages := map[string]string{
+		"required":      "is required",
+		"min=18":        "must be at least 18",
+		"in=admin,user": "must be among admin, user",
+		"gtField=Start": "must be greater than Start",
+	}
+	for tag, message := range messages {
+		if got := validator.ParseTag(tag)[0].Message(); got != message {
+			T.Errorf("%s: expected %q, got %q", tag, message, got)
+		}
+	}
+
+	var fieldError validator.FieldError
+	errors.As(validator.Var([]string{"root"}, "in=admin,user"), &fieldError)
+	if got := fieldError.Violations[0].Message(); got != "must be among admin, user" {
+		T.Errorf("unexpected violation message %q", got)
+	}
+}
+
+func TestDescribe(T *testing.T) {
+	description := validator.Describe(Signup{})
+	if description.Type != "Signup" || len(description.Fields) != 3 {
+		T.Fatalf("unexpected description %+v", description)
+	}
+	age := description.Fields[2]
+	if age.Path != "age" || age.Type != "int" || age.Message != "must be at least 18; must be at most 130" {
+		T.Errorf("unexpected field description %+v", age)
+	}
+	if markdown := description.Markdown(); !strings.Contains(markdown, "| `age` | `int` | `min=18` `max=130` | must be at least 18; must be at most 130 |") {
+		T.Errorf("unexpected markdown\n%s", markdown)
+	}
+	if html := description.HTML(); !strings.Contains(html, "<caption>Signup</caption>") || !strings.Contains(html, "<td><code>age</code></td>") {
+		T.Errorf("unexpected html\n%s", html)
+	}
+
+	v := validator.New()
+	v.RegisterStructRules(Address{}, map[string]string{"Line1": "required"})
+	v.Describe(Address{}).Fields[0].Rules[0] = validator.Constraint{}
+	if err := v.Struct(Address{Country: "DZ"}); !errors.Is(err, validator.ErrRequired) {
+		T.Errorf("expected the registered rules to be copied, got %v", err)
+	}
+}
+
+func TestSchema(T *testing.T) {
+	schema := validator.Schema(User{})
+	id := schema.Fields[0]
+	if id.Path != "BaseModel.ID" || id.JSONName != "id" || id.Kind != reflect.String || id.Constraints[0].Param != int64(10) {
+		T.Errorf("unexpected field schema %+v", id)
+	}
+	role := schema.Fields[4]
+	if role.Name != "Role" || role.Kind != reflect.Struct || role.Struct == nil || role.Struct.Name != "Role" {
+		T.Fatalf("unexpected field schema %+v", role)
+	}
+	if users := role.Struct.Fields[len(role.Struct.Fields)-1]; users.Struct != schema {
+		T.Errorf("expected the users field to refer to the User schema, got %+v", users.Struct)
+	}
+
+	invite := validator.Schema(Invite{})
+	if exp, ok := invite.Fields[0].Constraints[0].Param.(*regexp.Regexp); !ok || !exp.MatchString("ABC-12") {
+		T.Errorf("expected a compiled regexp, got %#v", invite.Fields[0].Constraints[0].Param)
+	}
+	if roles := invite.Fields[2].Constraints[0].Param; !reflect.DeepEqual(roles, []string{"admin", "user"}) {
+		T.Errorf("expected a list param, got %#v", roles)
+	}
+}
+
+func TestAttrs(T *testing.T) {
+	attrs := map[string]template.HTMLAttr{
+		"Name":  `pattern="[\s\S]*(?:^[a-zA-Z]+$)[\s\S]*"`,
+		"Email": `type="email"`,
+		"Age":   `type="number" min="18" max="130"`,
+	}
+	for field, expected := range attrs {
+		if got := validator.Attrs(Signup{}, field); got != expected {
+			T.Errorf("%s: expected %s, got %s", field, expected, got)
+		}
+	}
+
+	type code struct {
+		Code string `validate:"match=^a|b$"`
+	}
+	if got := validator.Attrs(code{}, "Code"); got != `pattern="[\s\S]*(?:^a|b$)[\s\S]*"` {
+		T.Errorf("expected the alternation to be grouped, got %s", got)
+	}
+
+	tmpl := template.Must(template.New("form").Funcs(validator.FuncMap()).Parse(`<input name="age" {{ validatorAttrs . "Age" }}>`))
+	html := strings.Builder{}
+	if err := tmpl.Execute(&html, Signup{}); err != nil {
+		T.Fatal(err)
+	}
+	if html.String() != `<input name="age" type="number" min="18" max="130">` {
+		T.Errorf("unexpected html %s", html.String())
+	}
+}
+
+func TestHandler(T *testing.T) {
+	handler := validator.Handler(func(w http.ResponseWriter, r *http.Request, signup Signup) {
+		w.WriteHeader(http.StatusCreated)
+	})
+	bodies := map[string]int{
+		`{"name":"johnny","email":"john@example.com","age":30}`: http.StatusCreated,
+		`{"name":"jo","email":"john@example.com","age":30}`:     http.StatusUnprocessableEntity,
+		`{"name":`: http.StatusBadRequest,
+		``:         http.StatusBadRequest,
+		`{} {}`:    http.StatusBadRequest,
+		`null`:     http.StatusBadRequest,
+	}
+	for body, status := range bodies {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+		if w.Code != status {
+			T.Errorf("%s: expected %d, got %d %s", body, status, w.Code, w.Body)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"jo","email":"john@example.com","age":30}`)))
+	if !strings.Contains(w.Body.String(), `"fieldsErrors":[{"field":"name","value":"jo","struct":"Signup","violations":[{"kind":"minLen","param":5}]}]`) {
+		T.Errorf("unexpected error body %s", w.Body)
+	}
+
+	v := validator.New(validator.MaxBodySize(32), validator.DisallowUnknownFields())
+	var decodeError *validator.DecodeError
+	_, err := validator.DecodeJSONWith[Signup](v, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"nickname":"jo"}`)))
+	if !errors.As(err, &decodeError) || decodeError.Status != http.StatusBadRequest || decodeError.Err.Error() != `unknown field "nickname"` {
+		T.Errorf("expected unknown field error, got %v", err)
+	}
+	_, err = validator.DecodeJSON[Signup](httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"age":"30"}`)))
+	var typeError *json.UnmarshalTypeError
+	if !errors.As(err, &decodeError) || decodeError.Err.Error() != `field "age" must be an integer, got string` || !errors.As(err, &typeError) {
+		T.Errorf("expected a type error naming the JSON field, got %v", err)
+	}
+	_, err = validator.DecodeJSON[*Signup](httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`[]`)))
+	if !errors.As(err, &decodeError) || decodeError.Err.Error() != `body must be an object, got array` {
+		T.Errorf("expected a type error naming the JSON type, got %v", err)
+	}
+	_, err = validator.DecodeJSONWith[*Signup](v, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"johnny","email":"john@example.com"}`)))
+	if !errors.As(err, &decodeError) || decodeError.Status != http.StatusRequestEntityTooLarge {
+		T.Errorf("expected body too large error, got %v", err)
+	}
+
+	server := httptest.NewServer(validator.HandlerWith(v, func(w http.ResponseWriter, r *http.Request, signup Signup) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+	res, err := http.Post(server.URL, "application/json", strings.NewReader(`{"name":"johnny","email":"john@example.com","age":30}`))
+	if err != nil {
+		T.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusRequestEntityTooLarge || !res.Close {
+		T.Errorf("expected the connection to be closed after a body too large, got %d close=%v", res.StatusCode, res.Close)
+	}
+}
+
+type Search struct {
+	Query string     `query:"q" validate:"required;minLen=2"`
+	Page  int        `query:"page" validate:"min=1"`
+	Tags  []string   `query:"tag" validate:"in=go,rust"`
+	Since *time.Time `query:"since"`
+	Exact bool       `form:"exact"`
+}
+
+func TestBind(T *testing.T) {
+	var search Search
+	values := url.Values{"q": {"go"}, "page": {"2"}, "tag": {"go", "rust"}, "since": {"2024-01-02"}, "exact": {"true"}}
+	if err := validator.Bind(values, &search); err != nil {
+		T.Fatal(err)
+	}
+	if search.Query != "go" || search.Page != 2 || len(search.Tags) != 2 || search.Since == nil || search.Since.Day() != 2 || !search.Exact {
+		T.Errorf("unexpected bound value %+v", search)
+	}
+
+	search = Search{}
+	r := httptest.NewRequest(http.MethodGet, "/?q=go&page=two&since=yesterday", nil)
+	err := validator.BindQuery(r, &search)
+	var e *validator.Error
+	if !errors.As(err, &e) || !errors.Is(err, validator.ErrType) {
+		T.Fatalf("expected type violations, got %v", err)
+	}
+	page := e.Field("page")
+	if page == nil || page.Value != "two" || len(page.Violations) != 2 || page.Violations[0].Kind != "type" || page.Violations[1].Kind != "min" {
+		T.Errorf("expected type and min violations, got %+v", page)
+	}
+	if !e.HasViolation("since", "type") {
+		T.Errorf("expected since type violation, got %v", err)
+	}
+
+	search = Search{}
+	r = httptest.NewRequest(http.MethodPost, "/?page=3", strings.NewReader("q=rust&exact=1"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if err := validator.BindForm(r, &search); err != nil || search.Query != "rust" || search.Page != 3 || !search.Exact {
+		T.Errorf("unexpected form binding %+v: %v", search, err)
+	}
+
+	var terms struct {
+		Accept bool `form:"accept" validate:"required"`
+	}
+	if err := validator.Bind(url.Values{"accept": {"on"}}, &terms); err != nil || !terms.Accept {
+		T.Errorf("expected a checked checkbox, got %+v: %v", terms, err)
+	}
+	if err := validator.Bind(url.Values{}, &terms); !errors.Is(err, validator.ErrRequired) || terms.Accept {
+		T.Errorf("expected an unchecked checkbox, got %+v: %v", terms, err)
+	}
+
+	var subscription struct {
+		Email string `form:"email" mod:"trim;lower" validate:"email"`
+	}
+	if err := validator.Bind(url.Values{"email": {"  Joe@Example.com "}}, &subscription); err != nil || subscription.Email != "joe@example.com" {
+		T.Errorf("expected the email to be sanitized before the rules, got %q: %v", subscription.Email, err)
+	}
+
+	var paging struct {
+		Page int `query:"page" default:"1" validate:"min=1"`
+	}
+	v := validator.New(validator.WithDefaults())
+	if err := v.Bind(url.Values{}, &paging); err != nil || paging.Page != 1 {
+		T.Errorf("expected the default page, got %d: %v", paging.Page, err)
+	}
+}
+
+type Config struct {
+	DatabaseURL string        `env:"TEST_DB_URL" validate:"required;url"`
+	Port        int           `env:"TEST_PORT" envDefault:"8080" validate:"min=1;max=65535"`
+	Hosts       []string      `env:"TEST_HOSTS" envSeparator:";" validate:"required"`
+	Timeout     time.Duration `env:"TEST_TIMEOUT"`
+}
+
+func TestLoadEnv(T *testing.T) {
+	T.Setenv("TEST_DB_URL", "https://db.example.com")
+	T.Setenv("TEST_HOSTS", "a.example.com; b.example.com")
+	T.Setenv("TEST_TIMEOUT", "30s")
+	var cfg Config
+	if err := validator.LoadEnv(&cfg); err != nil {
+		T.Fatal(err)
+	}
+	if cfg.Port != 8080 || cfg.Timeout != 30*time.Second || !reflect.DeepEqual(cfg.Hosts, []string{"a.example.com", "b.example.com"}) {
+		T.Errorf("unexpected config %+v", cfg)
+	}
+
+	T.Setenv("TEST_DB_URL", "")
+	T.Setenv("TEST_PORT", "http")
+	T.Setenv("TEST_HOSTS", "")
+	err := validator.LoadEnv(&Config{})
+	var e *validator.Error
+	if !errors.As(err, &e) || len(e.FieldsErrors) != 3 {
+		T.Fatalf("expected 3 fields errors, got %v", err)
+	}
+	if !e.HasViolation("TEST_DB_URL", "required") || !e.HasViolation("TEST_PORT", "type") || !e.HasViolation("TEST_HOSTS", "required") {
+		T.Errorf("unexpected errors %v", err)
+	}
+}
+
+func TestLoadConfig(T *testing.T) {
+	path := T.TempDir() + "/signup.json"
+	os.WriteFile(path, []byte("{\n  \"name\": \"jo\",\n  \"email\": \"john@example.com\",\n  \"age\": 10\n}\n"), 0o644)
+	err := validator.LoadConfig(path, &Signup{})
+	var e *validator.Error
+	if !errors.As(err, &e) {
+		T.Fatalf("expected validation errors, got %v", err)
+	}
+	if name := e.Field("name"); name == nil || name.Position.String() != path+":2:3" {
+		T.Errorf("unexpected name error %v", name)
+	}
+	if age := e.Field("age"); age == nil || age.Position.String() != path+":4:3" {
+		T.Errorf("unexpected age error %v", age)
+	}
+
+	os.WriteFile(path, []byte("{\n  \"name\": \"johnny\",\n  \"age\": \"ten\"\n}\n"), 0o644)
+	var positionError *validator.PositionError
+	if err := validator.LoadConfig(path, &Signup{}); !errors.As(err, &positionError) || positionError.Line != 3 {
+		T.Errorf("expected a located decode error, got %v", err)
+	}
+
+	validator.RegisterConfigDecoder(".conf", func(data []byte, dst any) (map[string]validator.Position, error) {
+		return map[string]validator.Position{"age": {Line: 7, Column: 1}}, json.Unmarshal(data, dst)
+	})
+	path = T.TempDir() + "/signup.conf"
+	os.WriteFile(path, []byte(`{"name":"johnny","email":"john@example.com","age":200}`), 0o644)
+	if err := validator.LoadConfig(path, &Signup{}); err == nil || !strings.HasPrefix(err.(*validator.Error).FieldsErrors[0].Error(), path+":7:1: validate: field age") {
+		T.Errorf("expected the decoder positions, got %v", err)
+	}
+}
+
+func TestMap(T *testing.T) {
+	var data map[string]any
+	json.Unmarshal([]byte(`{
+		"event": "order.created",
+		"order": {"id": "A-1", "total": 42.5, "currency": "usd"},
+		"items": [{"sku": "KB-1", "quantity": 2}, {"sku": "", "quantity": 0}],
+		"tags": ["new", "vip"],
+		"password": "secret123", "confirm": "secret124"
+	}`), &data)
+	err := validator.Map(data, map[string]string{
+		"event":             "required;in=order.created,order.paid",
+		"order.id":          "required;minLen=4",
+		"order.total":       "min=0",
+		"order.currency":    "required;in=EUR,USD",
+		"items[*].sku":      "required",
+		"items[*].quantity": "min=1",
+		"tags":              "include=vip",
+		"customer.email":    "required;email",
+		"confirm":           "eqField=password",
+	})
+	var e *validator.Error
+	if !errors.As(err, &e) {
+		T.Fatalf("expected errors, got %v", err)
+	}
+	expected := map[string]string{
+		"order.id":          "minLen",
+		"order.currency":    "in",
+		"items[1].sku":      "required",
+		"items[1].quantity": "min",
+		"customer.email":    "required",
+		"confirm":           "eqField",
+	}
+	if len(e.FieldsErrors) != len(expected) {
+		T.Errorf("expected %d fields errors, got %v", len(expected), err)
+	}
+	for path, kind := range expected {
+		if !e.HasViolation(path, kind) {
+			T.Errorf("expected %s violation on %s, got %v", kind, path, err)
+		}
+	}
+
+	json.Unmarshal([]byte(`{"age": "abc", "email": 42, "name": true, "tags": "vip", "score": 7}`), &data)
+	err = validator.Map(data, map[string]string{
+		"age":   "min=18",
+		"email": "required;email",
+		"name":  "minLen=2",
+		"tags":  "include=vip",
+		"score": "in=7,8",
+	})
+	if !errors.As(err, &e) || len(e.FieldsErrors) != 5 {
+		T.Fatalf("expected 5 type violations, got %v", err)
+	}
+	for path, typeName := range map[string]string{"age": "number", "email": "string", "name": "string", "tags": "array", "score": "string"} {
+		if !e.HasViolation(path, "type") {
+			T.Errorf("expected type violation on %s, got %v", path, err)
+			continue
+		}
+		for _, fieldError := range e.FieldsErrors {
+			if fieldError.Field == path && fieldError.Violations[0].Param != typeName {
+				T.Errorf("expected %s to be a %s, got %v", path, typeName, fieldError.Violations[0].Param)
+			}
+		}
+	}
+}
+
+func TestStreamJSON(T *testing.T) {
+	records := `{"name":"johnny","email":"john@example.com","age":30}
+{"name":"jo","email":"jo@example.com","age":30}
+{"name":"johnny","email":"john@example.com","age":"thirty"}
+{"name":"janet","email":"janet@example.com","age":40}
+`
+	for _, input := range []string{records, "[" + strings.ReplaceAll(strings.TrimSpace(records), "\n", ",\n") + "]"} {
+		invalid := []int{}
+		count := 0
+		err := validator.StreamJSON(strings.NewReader(input), func(index int, signup Signup, err error) error {
+			count++
+			if err != nil {
+				invalid = append(invalid, index)
+			}
+			return nil
+		})
+		if err != nil || count != 4 || !reflect.DeepEqual(invalid, []int{1, 2}) {
+			T.Errorf("unexpected stream result %d records, invalid %v: %v", count, invalid, err)
+		}
+	}
+
+	count := 0
+	err := validator.StreamJSON(strings.NewReader(records), func(index int, signup Signup, err error) error {
+		count++
+		return nil
+	}, validator.StopAfter(1))
+	if !errors.Is(err, validator.ErrTooManyInvalid) || count != 2 {
+		T.Errorf("expected to stop at the second record, got %d: %v", count, err)
+	}
+
+	var recordError *validator.RecordError
+	err = validator.StreamJSON(strings.NewReader(records+"{"), func(int, Signup, error) error { return nil })
+	if !errors.As(err, &recordError) || recordError.Index != 4 {
+		T.Errorf("expected a record error, got %v", err)
+	}
+}
+
+type Payment struct {
+	Reference string  `csv:"reference" validate:"required;alphaNumeric"`
+	Amount    float64 `csv:"amount" validate:"min=0"`
+	Currency  string  `csv:"currency" validate:"in=EUR,USD"`
+}
+
+func TestReadCSV(T *testing.T) {
+	input := "reference,amount,currency\nA1,10.5,EUR\nA2,-3,GBP\n,ten,USD\nA4,0,USD\n"
+	rejected := strings.Builder{}
+	rows := []int{}
+	summary, err := validator.ReadCSV(strings.NewReader(input), func(row int, payment Payment, err error) error {
+		if err != nil {
+			rows = append(rows, row)
+		}
+		return nil
+	}, validator.RejectedRows(&rejected))
+	if err != nil {
+		T.Fatal(err)
+	}
+	expected := validator.CSVSummary{Rows: 4, Valid: 2, Invalid: 2, Rules: map[string]int{"min": 1, "in": 1, "required": 1, "type": 1}}
+	if !reflect.DeepEqual(summary, expected) || !reflect.DeepEqual(rows, []int{3, 4}) {
+		T.Errorf("unexpected summary %+v, invalid rows %v", summary, rows)
+	}
+	if !strings.Contains(rejected.String(), "reference,amount,currency,errors\nA2,-3,GBP,\"amount: must be at least 0; currency: must be among EUR, USD\"\n,ten,USD,reference: is required; amount: must be a valid float\n") {
+		T.Errorf("unexpected rejected rows\n%s", rejected.String())
+	}
+
+	_, err = validator.ReadCSV(strings.NewReader(input), func(row int, payment Payment, err error) error {
+		var e *validator.Error
+		if errors.As(err, &e) && row == 3 && e.Field("amount").Value != "-3" {
+			T.Errorf("expected the raw cell, got %#v", e.Field("amount").Value)
+		}
+		return nil
+	})
+	if err != nil {
+		T.Error(err)
+	}
+
+	type grant struct {
+		Start  time.Time `csv:"start"`
+		Secret time.Time `csv:"secret" validate:"gtField=Start;sensitive"`
+		Code   string    `csv:"code" validate:"len=4"`
+	}
+	grants := "start,secret,code\n2021-01-01T00:00:00Z,2020-01-01T00:00:00Z,abc\n"
+	for _, v := range []*validator.Validator{validator.New(), validator.New(validator.WithValuePolicy(validator.IncludeNone))} {
+		_, err = validator.ReadCSV(strings.NewReader(grants), func(row int, g grant, err error) error {
+			e, _ := err.(*validator.Error)
+			if e == nil || len(e.FieldsErrors) != 2 {
+				T.Fatalf("expected 2 field errors, got %v", err)
+			}
+			if secret := e.Field("secret"); secret.Value != nil || !secret.Redacted {
+				T.Errorf("expected the secret cell to be redacted, got %+v", secret)
+			}
+			return nil
+		}, validator.WithValidator(v))
+		if err != nil {
+			T.Error(err)
+		}
+	}
+}
+
+func TestSlice(T *testing.T) {
+	signups := make([]*Signup, 1000)
+	for i := range signups {
+		signups[i] = &Signup{Name: "johnny", Email: "john@example.com", Age: 30}
+		if i%100 == 7 {
+			signups[i].Age = 10
+		}
+	}
+	err := validator.Slice(signups, validator.Workers(8))
+	var e *validator.SliceError
+	if !errors.As(err, &e) || len(e.Errors) != 10 || !errors.Is(err, validator.ErrMin) {
+		T.Fatalf("expected 10 invalid items, got %v", err)
+	}
+	for i, itemError := range e.Errors {
+		if itemError.Index != i*100+7 {
+			T.Errorf("expected item %d, got %d", i*100+7, itemError.Index)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := validator.SliceContext(ctx, signups); !errors.Is(err, context.Canceled) {
+		T.Errorf("expected context canceled, got %v", err)
+	}
+	if err := validator.SliceContext(lateContext{context.Background()}, signups); !errors.As(err, &e) {
+		T.Errorf("expected the items errors once every item was validated, got %v", err)
+	}
+}
+
+// lateContext is a context canceled once every item was handed to the workers.
+type lateContext struct {
+	context.Context
+}
+
+func (lateContext) Err() error {
+	return context.Canceled
+}
+
+type Contact struct {
+	Name    string   `mod:"trim;collapseSpaces;title" validate:"minLen=3"`
+	Email   *string  `sanitize:"trim;lower" validate:"email"`
+	Bio     string   `mod:"stripTags"`
+	Tags    []string `mod:"trim;upper"`
+	Comment string
+}
+
+func TestSanitize(T *testing.T) {
+	email := "  John@Example.COM "
+	contact := Contact{
+		Name:    "  john   de  lacy ",
+		Email:   &email,
+		Bio:     "<b>Café</b> owner",
+		Tags:    []string{" a ", "b"},
+		Comment: "  kept  ",
+	}
+	if err := validator.Struct(&contact); err != nil {
+		T.Fatalf("expected no error, got %v", err)
+	}
+	expected := Contact{Name: "John De Lacy", Email: &email, Bio: "Café owner", Tags: []string{"A", "B"}, Comment: "  kept  "}
+	if !reflect.DeepEqual(contact, expected) || email != "john@example.com" {
+		T.Errorf("expected %+v, got %+v with email %q", expected, contact, email)
+	}
+
+	value := Contact{Name: " jo  "}
+	if err := validator.Struct(value); err != nil {
+		T.Errorf("expected the copy not to be sanitized, got %v", err)
+	}
+	if err := validator.Sanitize(&value); err != nil || value.Name != "Jo" {
+		T.Errorf("expected Jo without error, got %q and %v", value.Name, err)
+	}
+
+	type unknown struct {
+		Name string `mod:"trim"`
+		Bio  string `mod:"nfc"`
+	}
+	value2 := unknown{Name: " jo "}
+	if err := validator.Struct(&value2); err == nil || !strings.Contains(err.Error(), `unknown modifier "nfc"`) || value2.Name != " jo " {
+		T.Errorf("expected an unknown modifier error leaving the struct unchanged, got %q and %v", value2.Name, err)
+	}
+	if err := validator.Slice([]unknown{{}}); err == nil || !strings.Contains(err.Error(), `unknown modifier "nfc"`) {
+		T.Errorf("expected an unknown modifier error, got %v", err)
+	}
+	if err := validator.Sanitize((*Contact)(nil)); err != nil {
+		T.Errorf("expected a nil pointer to be ignored, got %v", err)
+	}
+	if errs := validator.LintModifiers("trim;Lower;nfc;slug", "slug"); len(errs) != 2 {
+		T.Errorf("expected 2 errors, got %v", errs)
+	}
+}
+
+type Limits struct {
+	Retries int `default:"3" validate:"min=1"`
+}
+
+type Server struct {
+	Host    string        `default:"localhost"`
+	Port    int           `validate:"default=8080;min=1;max=65535"`
+	Debug   bool          `default:"true"`
+	Timeout time.Duration `default:"5s"`
+	Since   time.Time     `default:"2024-01-02T15:04:05Z"`
+	Origins []string      `default:"a.com, b.com"`
+	Ratio   *float64      `default:"0.5"`
+	Limits  Limits
+	Backup  *Limits
+}
+
+func TestApplyDefaults(T *testing.T) {
+	server := Server{Port: 9000, Backup: &Limits{}}
+	validator.ApplyDefaults(&server)
+	validator.ApplyDefaults((*Server)(nil))
+	ratio := 0.5
+	expected := Server{
+		Host:    "localhost",
+		Port:    9000,
+		Debug:   true,
+		Timeout: 5 * time.Second,
+		Since:   time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC),
+		Origins: []string{"a.com", "b.com"},
+		Ratio:   &ratio,
+		Limits:  Limits{Retries: 3},
+		Backup:  &Limits{Retries: 3},
+	}
+	if !reflect.DeepEqual(server, expected) {
+		T.Errorf("expected %+v, got %+v", expected, server)
+	}
+
+	v := validator.New(validator.WithDefaults())
+	limits := Limits{}
+	if err := v.Struct(&limits); err != nil || limits.Retries != 3 {
+		T.Errorf("expected 3 retries without error, got %d and %v", limits.Retries, err)
+	}
+	if err := v.Struct(Limits{}); !errors.Is(err, validator.ErrMin) {
+		T.Errorf("expected the copy to keep its zero value, got %v", err)
+	}
+
+	port := validator.JSONSchema(Server{})["properties"].(map[string]any)["Port"].(map[string]any)
+	if port["default"] != 8080 {
+		T.Errorf("expected the default port in the schema, got %v", port)
+	}
+	if errs := validator.LintTag("default=soon", validator.LintField{Kind: reflect.Int64}); len(errs) != 1 {
+		T.Errorf("expected an invalid default, got %v", errs)
+	}
+	if errs := validator.LintTag("default=5s", validator.LintField{Kind: reflect.Int64}); len(errs) != 0 {
+		T.Errorf("expected a valid duration default, got %v", errs)
+	}
+}
@@ -0,0 +1,71 @@
+package validator
+
+import (
+	"reflect"
+)
+
+type signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+type unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+type float interface {
+	~float32 | ~float64
+}
+
+// Var validates a standalone value against tag, the returned error is a FieldError.
+func Var(value any, tag string) error {
+	return defaultValidator.Var(value, tag)
+}
+
+// VarWithValue validates value against tag, the field comparison rules
+// (eqField, gtField, ...) compare it with other.
+func VarWithValue(value, other any, tag string) error {
+	return defaultValidator.VarWithValue(value, other, tag)
+}
+
+func String[T ~string](value T, tag string) error {
+	return defaultValidator.Var(value, tag)
+}
+
+func Int[T signed](value T, tag string) error {
+	return defaultValidator.Var(value, tag)
+}
+
+func Uint[T unsigned](value T, tag string) error {
+	return defaultValidator.Var(value, tag)
+}
+
+func Float[T float](value T, tag string) error {
+	return defaultValidator.Var(value, tag)
+}
+
+func (v *Validator) Var(value any, tag string) error {
+	return v.validateVar(reflect.ValueOf(value), nil, tag)
+}
+
+func (v *Validator) VarWithValue(value, other any, tag string) error {
+	return v.validateVar(reflect.ValueOf(value), func(string) (reflect.Value, bool) {
+		return reflect.ValueOf(other), true
+	}, tag)
+}
+
+func (v *Validator) validateVar(rv reflect.Value, field func(string) (reflect.Value, bool), tag string) error {
+	constraints := parseConstraints(tag)
+	check := &rulesCheck{
+		bail:  v.bail || hasConstraint(constraints, bail),
+		field: field,
+	}
+	fieldError := FieldError{
+		Value: check.value(rv, constraints),
+	}
+	if len(check.violations) == 0 {
+		return nil
+	}
+	fieldError.Violations = check.violations
+	v.redact(&fieldError, "", constraints)
+	return fieldError
+}
@@ -0,0 +1,94 @@
+package validator
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var plainYAMLString = regexp.MustCompile(`^[a-zA-Z_$][a-zA-Z0-9_$./#-]*$`)
+
+// marshalYAML encodes the maps, slices and scalars produced by the schema
+// generators, map keys are sorted so the output is stable.
+func marshalYAML(value any) []byte {
+	buf := &bytes.Buffer{}
+	writeYAML(buf, reflect.ValueOf(value), 0)
+	return buf.Bytes()
+}
+
+func writeYAML(buf *bytes.Buffer, v reflect.Value, indent int) {
+	prefix := strings.Repeat("  ", indent)
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Map:
+		keys := []string{}
+		for _, key := range v.MapKeys() {
+			keys = append(keys, fmt.Sprint(key.Interface()))
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			value := v.MapIndex(reflect.ValueOf(key))
+			buf.WriteString(prefix + yamlString(key) + ":")
+			writeYAMLValue(buf, value, indent)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			buf.WriteString(prefix + "-")
+			writeYAMLValue(buf, v.Index(i), indent)
+		}
+	}
+}
+
+func writeYAMLValue(buf *bytes.Buffer, v reflect.Value, indent int) {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	switch {
+	case (v.Kind() == reflect.Map || v.Kind() == reflect.Slice) && v.Len() == 0:
+		if v.Kind() == reflect.Map {
+			buf.WriteString(" {}\n")
+		} else {
+			buf.WriteString(" []\n")
+		}
+	case v.Kind() == reflect.Map || v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		buf.WriteString("\n")
+		writeYAML(buf, v, indent+1)
+	default:
+		buf.WriteString(" " + yamlScalar(v) + "\n")
+	}
+}
+
+func yamlScalar(v reflect.Value) string {
+	switch {
+	case !v.IsValid():
+		return "null"
+	case v.Kind() == reflect.String:
+		return yamlString(v.String())
+	case v.Kind() == reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case isIntKind(v.Kind()):
+		return strconv.FormatInt(v.Int(), 10)
+	case isUintKind(v.Kind()):
+		return strconv.FormatUint(v.Uint(), 10)
+	case isFloatKind(v.Kind()):
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	}
+	return yamlString(fmt.Sprint(v.Interface()))
+}
+
+func yamlString(s string) string {
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "null", "y", "n", "~":
+		return strconv.Quote(s)
+	}
+	if plainYAMLString.MatchString(s) {
+		return s
+	}
+	return strconv.Quote(s)
+}
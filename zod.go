@@ -0,0 +1,270 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var zodComparisons = map[string]string{
+	eqField:  "===",
+	neField:  "!==",
+	gtField:  ">",
+	gteField: ">=",
+	ltField:  "<",
+	lteField: "<=",
+}
+
+// Zod returns a typescript module declaring an interface and a zod schema per
+// struct, the nested structs are declared after the given ones. A struct named
+// like a struct of another package is qualified by its package name, such as
+// pb_Item.
+func Zod(values ...any) string {
+	return defaultValidator.Zod(values...)
+}
+
+func (v *Validator) Zod(values ...any) string {
+	b := &zodBuilder{v: v, names: map[reflect.Type]string{}, taken: map[string]bool{}}
+	for _, value := range values {
+		b.declare(indirect(reflect.TypeOf(value)))
+	}
+	return "import { z } from \"zod\";\n" + strings.Join(b.decls, "")
+}
+
+type zodBuilder struct {
+	v     *Validator
+	names map[reflect.Type]string
+	taken map[string]bool
+	decls []string
+}
+
+type zodField struct {
+	name     string
+	tsType   string
+	schema   string
+	optional bool
+}
+
+func (b *zodBuilder) declare(t reflect.Type) string {
+	if name, ok := b.names[t]; ok {
+		return name
+	}
+	name := uniqueName(t, "_", func(name string) bool {
+		return b.taken[name]
+	})
+	b.names[t] = name
+	b.taken[name] = true
+	index := len(b.decls)
+	b.decls = append(b.decls, "")
+
+	fields := []zodField{}
+	refines := []string{}
+	b.fields(t, b.v.scopes(nil, t, ""), &fields, &refines)
+
+	decl := strings.Builder{}
+	fmt.Fprintf(&decl, "\nexport interface %s {\n", name)
+	for _, field := range fields {
+		optional := ""
+		if field.optional {
+			optional = "?"
+		}
+		fmt.Fprintf(&decl, "  %s%s: %s;\n", jsKey(field.name), optional, field.tsType)
+	}
+	fmt.Fprintf(&decl, "}\n\nexport const %sSchema: z.ZodType<%s> = z.lazy(() =>\n  z\n    .object({\n", name, name)
+	for _, field := range fields {
+		fmt.Fprintf(&decl, "      %s: %s,\n", jsKey(field.name), field.schema)
+	}
+	decl.WriteString("    })")
+	for _, refine := range refines {
+		decl.WriteString("\n    " + refine)
+	}
+	decl.WriteString("\n);\n")
+	b.decls[index] = decl.String()
+	return name
+}
+
+func (b *zodBuilder) fields(t reflect.Type, scopes []rulesScope, fields *[]zodField, refines *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		ft := t.Field(i)
+		if !ft.IsExported() {
+			continue
+		}
+		name := fieldName(ft)
+		if name == "-" {
+			continue
+		}
+		if ft.Anonymous && ft.Type.Kind() == reflect.Struct && name == ft.Name {
+			b.fields(ft.Type, b.v.scopes(scopes, ft.Type, ft.Name), fields, refines)
+			continue
+		}
+		constraints, _ := b.v.fieldConstraints(scopes, ft)
+		check := &rulesCheck{structName: t.Name(), name: ft.Name}
+		tsType, schema := b.typeSchema(ft.Type)
+		schema += b.rules(ft.Type, constraints, check)
+
+		isRequired := hasConstraint(constraints, required)
+		nullable := ft.Type.Kind() == reflect.Pointer || ft.Type.Kind() == reflect.Slice || ft.Type.Kind() == reflect.Map
+		optional := strings.Contains(ft.Tag.Get("json"), ",omitempty")
+		if nullable && !isRequired {
+			schema += ".nullish()"
+			tsType += " | null"
+			optional = true
+		} else if optional && !isRequired {
+			schema += ".optional()"
+		}
+		*fields = append(*fields, zodField{
+			name:     name,
+			tsType:   tsType,
+			schema:   schema,
+			optional: optional && !isRequired,
+		})
+
+		for _, constraint := range constraints {
+			op, ok := zodComparisons[constraint.Kind]
+			if !ok {
+				continue
+			}
+			param, _ := getStringParam(constraint.Param)
+			other, ok := t.FieldByName(param)
+			if !ok {
+				check.invalidParam(constraint)
+			}
+			a, c := "o"+jsAccess(name), "o"+jsAccess(fieldName(other))
+			*refines = append(*refines, fmt.Sprintf(".refine((o) => %s == null || %s == null || %s %s %s, { path: [%s], message: %s })",
+				a, c, a, op, c, jsString(name), jsString(constraint.Tag)))
+		}
+	}
+}
+
+func (b *zodBuilder) typeSchema(t reflect.Type) (string, string) {
+	t = indirect(t)
+	switch {
+	case t == timeType:
+		return "string", "z.string().datetime({ offset: true })"
+	case t.Kind() == reflect.String:
+		return "string", "z.string()"
+	case t.Kind() == reflect.Bool:
+		return "boolean", "z.boolean()"
+	case isIntKind(t.Kind()):
+		return "number", "z.number().int()"
+	case isUintKind(t.Kind()):
+		return "number", "z.number().int().nonnegative()"
+	case isFloatKind(t.Kind()):
+		return "number", "z.number()"
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return "string", "z.string().base64()"
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		tsType, schema := b.typeSchema(t.Elem())
+		if strings.Contains(tsType, " ") {
+			tsType = "(" + tsType + ")"
+		}
+		return tsType + "[]", "z.array(" + schema + ")"
+	case t.Kind() == reflect.Map:
+		tsType, schema := b.typeSchema(t.Elem())
+		return "Record<string, " + tsType + ">", "z.record(" + schema + ")"
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		name := b.declare(t)
+		return name, name + "Schema"
+	}
+	return "unknown", "z.unknown()"
+}
+
+// rules returns the checks of a field schema, the refines come after the native
+// checks since zod does not have these on the schema a refine returns.
+func (b *zodBuilder) rules(t reflect.Type, constraints []Constraint, check *rulesCheck) string {
+	t = indirect(t)
+	rules := strings.Builder{}
+	refines := strings.Builder{}
+	for _, constraint := range constraints {
+		if exp, ok := regexMap[constraint.Kind]; ok {
+			rules.WriteString(jsRegexRule(exp.String()))
+			continue
+		}
+		switch constraint.Kind {
+		case required:
+			if t.Kind() == reflect.String {
+				rules.WriteString(`.min(1, { message: "required" })`)
+			} else if isIntKind(t.Kind()) || isUintKind(t.Kind()) || isFloatKind(t.Kind()) {
+				refines.WriteString(`.refine((v) => v !== 0, { message: "required" })`)
+			}
+		case minLen, maxLen, length:
+			param, ok := getIntParam(constraint.Param)
+			if !ok {
+				check.invalidParam(constraint)
+			}
+			if t.Kind() == reflect.String {
+				// zod counts the UTF-16 code units of strings, len the UTF-8 bytes
+				op := map[string]string{minLen: ">=", maxLen: "<=", length: "==="}[constraint.Kind]
+				fmt.Fprintf(&refines, ".refine((v) => new TextEncoder().encode(v).length %s %d, { message: %s })", op, param, jsString(constraint.Kind))
+				continue
+			}
+			method := map[string]string{minLen: "min", maxLen: "max", length: "length"}[constraint.Kind]
+			fmt.Fprintf(&rules, ".%s(%d)", method, param)
+		case min, max:
+			param, ok := getFloatParam(constraint.Param)
+			if !ok {
+				check.invalidParam(constraint)
+			}
+			method := map[string]string{min: "gte", max: "lte"}[constraint.Kind]
+			fmt.Fprintf(&rules, ".%s(%v)", method, param)
+		case match:
+			param, ok := getStringParam(constraint.Param)
+			if !ok {
+				check.invalidParam(constraint)
+			}
+			rules.WriteString(jsRegexRule(param))
+		case in, oneOf, out, include, exclude:
+			values, _ := json.Marshal(listParam(t, constraint, check))
+			list := string(values)
+			var expr string
+			switch {
+			case t.Kind() != reflect.Slice && constraint.Kind == out:
+				expr = fmt.Sprintf("!%s.includes(v)", list)
+			case t.Kind() != reflect.Slice:
+				expr = fmt.Sprintf("%s.includes(v)", list)
+			case constraint.Kind == in:
+				expr = fmt.Sprintf("v.every((e) => %s.includes(e))", list)
+			case constraint.Kind == out:
+				expr = fmt.Sprintf("v.every((e) => !%s.includes(e))", list)
+			case constraint.Kind == include:
+				expr = fmt.Sprintf("%s.every((e) => v.includes(e))", list)
+			default:
+				expr = fmt.Sprintf("%s.every((e) => !v.includes(e))", list)
+			}
+			fmt.Fprintf(&refines, ".refine((v) => %s, { message: %s })", expr, jsString(constraint.Kind))
+		}
+	}
+	return rules.String() + refines.String()
+}
+
+func jsRegexRule(pattern string) string {
+	source, flags := jsRegexp(pattern)
+	if flags != "" {
+		return fmt.Sprintf(".regex(new RegExp(%s, %s))", jsString(source), jsString(flags))
+	}
+	return fmt.Sprintf(".regex(new RegExp(%s))", jsString(source))
+}
+
+func jsString(s string) string {
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func jsKey(name string) string {
+	if plainYAMLString.MatchString(name) && !strings.ContainsAny(name, "./#-") {
+		return name
+	}
+	return jsString(name)
+}
+
+func jsAccess(name string) string {
+	if key := jsKey(name); key == name {
+		return "." + name
+	}
+	return "[" + jsString(name) + "]"
+}